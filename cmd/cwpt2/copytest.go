@@ -0,0 +1,120 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/wa2nfn/cwpt/practice"
+	"github.com/wa2nfn/cwpt/score"
+)
+
+// scoreCopyFile compares a file of what the student copied with the items sent
+func scoreCopyFile(items []practice.CopyItem, name string) *score.Result {
+	copied, err := os.ReadFile(name)
+	if err != nil {
+		fmt.Printf("\n%s File name <%s>.\n", err, name)
+		os.Exit(exitFile)
+	}
+
+	r := score.Compare(practice.CopyText(items), string(copied))
+	r.Report(os.Stdout)
+
+	return r
+}
+
+// copyTest prompts for one item at a time, with when it starts in the WAV
+// the student plays, shows what was sent after each answer, then scores the
+// whole session
+func copyTest(items []practice.CopyItem, wav string, in io.Reader) *score.Result {
+	total := score.Compare("", "")
+	scanner := bufio.NewScanner(in)
+
+	fmt.Printf("\nCopy test: %d items. Play %s, pausing it after each item.\n", len(items), wav)
+	fmt.Printf("Each prompt shows when its item starts in the audio. Type what you heard then Enter, or \"q\" to quit.\n")
+
+	for i, item := range items {
+		secs := int(item.Start)
+		fmt.Printf("\n%d/%d at %d:%02d: ", i+1, len(items), secs/60, secs%60)
+		if !scanner.Scan() {
+			break
+		}
+
+		copied := scanner.Text()
+		if strings.TrimSpace(copied) == "q" {
+			break
+		}
+
+		r := score.Compare(item.Text, copied)
+		total.Merge(r)
+
+		if r.Correct == r.Sent && r.Insertions == 0 {
+			fmt.Printf("   ok  %s\n", item.Text)
+		} else {
+			fmt.Printf("   sent <%s> copied <%s>\n", item.Text, strings.TrimSpace(copied))
+		}
+	}
+
+	total.Report(os.Stdout)
+
+	return total
+}
+
+// saveProgress adds a scored session to the progress file
+func saveProgress(r *score.Result) {
+	p, err := score.LoadProgress(opts.Progress)
+	if err != nil {
+		fmt.Printf("\nError: %v.\n", err)
+		os.Exit(exitFile)
+	}
+
+	p.Add(r)
+
+	if err := p.Save(opts.Progress); err != nil {
+		fmt.Printf("\nError: %v.\n", err)
+		os.Exit(exitFile)
+	}
+
+	fmt.Printf("\nProgress saved to file: %s\n", opts.Progress)
+}
+
+// importProgress adds a results file, made elsewhere, to the progress file
+func importProgress(name string) {
+	if opts.Progress == "" {
+		fmt.Printf("\nError: option <progressImport> requires a progress file name.\n")
+		os.Exit(exitInvalid)
+	}
+
+	// a missing progress file is no progress yet, a missing import is a mistake
+	if _, err := os.Stat(name); err != nil {
+		fmt.Printf("\n%s File name <%s>.\n", err, name)
+		os.Exit(exitFile)
+	}
+
+	results, err := score.LoadProgress(name)
+	if err != nil {
+		fmt.Printf("\nError: %v.\n", err)
+		os.Exit(exitFile)
+	}
+
+	p, err := score.LoadProgress(opts.Progress)
+	if err != nil {
+		fmt.Printf("\nError: %v.\n", err)
+		os.Exit(exitFile)
+	}
+
+	p.Merge(results)
+
+	if err := p.Save(opts.Progress); err != nil {
+		fmt.Printf("\nError: %v.\n", err)
+		os.Exit(exitFile)
+	}
+
+	fmt.Printf("\nImported <%s> into progress file: %s\n", name, opts.Progress)
+}
@@ -0,0 +1,682 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+// Only my second GO program. Lots could be more GO like
+//
+// The practice text itself is made by package practice, this file just
+// turns the command line (and options file) into practice.Options.
+//
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/wa2nfn/cwpt/practice"
+	"github.com/wa2nfn/cwpt/score"
+)
+
+const (
+	prog    = "cwpt2"
+	program = "cwpt2.exe"
+	version = "1.3.0 04/25/2020"
+)
+
+// exit codes, also shown by -help=EXIT
+const (
+	exitOK      = 0 // practice text written, or version/help shown
+	exitName    = 1 // executable is misnamed
+	exitSyntax  = 2 // command line or options file can't be parsed (same as package flag)
+	exitInvalid = 3 // one or more option values are invalid, all of them are listed
+	exitNoWords = 4 // nothing in the input matched the options
+	exitFile    = 5 // a file could not be read or written
+)
+
+var (
+	opts        practice.Options
+	flagoutput  string
+	flagwav     string
+	flagkey     string
+	flagcopy    string
+	flagcopyTst bool
+	flagimport  string
+	flagopt     string
+	flagversion bool
+	flaghelp    string
+)
+
+func init() {
+	d := practice.DefaultOptions()
+
+	flag.IntVar(&opts.Max, "max", d.Max, "Maximum # characters in a word >= min. (Default 10)")
+	flag.IntVar(&opts.CGMax, "cgmax", d.CGMax, "Maximum # characters in a code group >= cgmin. (Default 5)")
+	flag.IntVar(&opts.Min, "min", d.Min, "Minimum # of characters in a word (or code group). (Default 1)")
+	flag.IntVar(&opts.CGMin, "cgmin", d.CGMin, "Minimum # of characters in a code group. (Default 5)")
+	flag.IntVar(&opts.Repeat, "repeat", d.Repeat, "Number of times to repeat word sequentially. (Default 1)")
+	flag.IntVar(&opts.Num, "num", d.Num, fmt.Sprintf("Number of words (or code groups) to output. Min 1, max %d.\n", practice.MaxUserWords))
+	flag.IntVar(&opts.Len, "len", d.Len, fmt.Sprintf("Length characters in an output line (max %d).", practice.MaxLineLen))
+	flag.IntVar(&opts.MinDits, "minDits", 0, "Minimum time to send a word, in dit units (dit 1, dah 3, 1 between elements,\n3 between characters), e.g. \"eieie\" is 21. (default 0, off)")
+	flag.IntVar(&opts.MaxDits, "maxDits", 0, "Maximum time to send a word, in dit units, e.g. \"qjyqj\" is 77. (default 0, off)")
+	flag.StringVar(&opts.DitOrder, "ditOrder", "", "Sort the words by time to send: asc (progressively longer) or desc. (default \"\", off)")
+	flag.StringVar(&opts.Rank, "rank", "", fmt.Sprintf("Choose words by how often they are in the in file (or freqList): %s\n(the num most frequent, ranks rankFrom to rankTo, or drawn as often as they are used).", strings.Join(practice.Ranks, ", ")))
+	flag.IntVar(&opts.RankFrom, "rankFrom", 0, "First rank for rank=band, 1 is the most frequent word.")
+	flag.IntVar(&opts.RankTo, "rankTo", 0, "Last rank for rank=band.")
+	flag.StringVar(&opts.FreqList, "freqList", "", "File of word frequencies to rank by, \"word count\" lines, or \"word\" lines most frequent first.")
+	flag.IntVar(&opts.Skip, "skip", 0, fmt.Sprintf("Number of the first unique words in the input to skip. Max %d", practice.MaxSkips))
+	flag.IntVar(&opts.Suffix, "suffix", 0, "The max number of suffix characters to append to words.")
+	flag.IntVar(&opts.Prefix, "prefix", 0, "The max number of prefix characters to affix to words.")
+	flag.BoolVar(&opts.Caps, "caps", false, "Print output in all capitals. (default lower case)")
+	flag.BoolVar(&flagversion, "version", false, "Display version information. (default false)")
+	flag.BoolVar(&opts.Random, "random", false, "If prefix/suffix is used, will determine if either is used on a\nword-by-word basis. (default false)")
+	flag.StringVar(&opts.Suflist, "suflist", d.Suflist, "Characters to append to a word. Suffix X, sets the quantity.")
+	flag.StringVar(&opts.Prelist, "prelist", d.Prelist, "Characters to insert before a word. Prefix X, sets the quantity.")
+	flag.StringVar(&opts.Inlist, "inlist", d.Inlist, "Set of characters to define an input word.")
+	flag.StringVar(&opts.Input, "in", "", "Input text file name (including extension). Without it words come from the built-in dict.\nA comma list of files, glob patterns (quoted) and directories (read recursively) reads them all,\n\"file:weight\" on each gives its share of the words, i.e. -in=novel.txt:70,ham.txt:30.\n\"-\" reads stdin, as does no in option when text is piped in, i.e. cat novel.txt | cwpt2 -lesson=12\n(a file redirected in, cwpt2 <novel.txt, needs -in=-).")
+	flag.StringVar(&opts.Dict, "dict", d.Dict, fmt.Sprintf("Built-in word list used when there is no in file: %s.\n(english is about 25,000 common words, most frequent first, ham is common ham radio words)", strings.Join(practice.Dicts, ", ")))
+	flag.StringVar(&opts.Vocab, "vocab", "", fmt.Sprintf("Built-in vocabulary, a comma list of: %s, or all (Q-codes, CW abbreviations, ham terms).\nWith or instead of the in file, a word of min to max inlist or cglist characters (like 73 or hw?). -key adds their meanings.", strings.Join(practice.VocabSets(), ", ")))
+	flag.IntVar(&opts.VocabRatio, "vocabRatio", d.VocabRatio, "Percent of output words from vocab when in is also used. (default 25)")
+	flag.StringVar(&flagoutput, "out", "", "Output file name.")
+	flag.StringVar(&flagopt, "opt", "", "Specify an options file name")
+	flag.StringVar(&opts.Prosign, "prosign", "", "ProSign file name. 1-4 TWO letter ProSigns per line.\n No space in between, as in \"<BT> <AR>\".\n<SOS> is the only 3 letter ProSign.")
+	flag.StringVar(&opts.Delimiter, "delimiter", "", "Output an inter-word delimiter string. A \"^\" separates delimiters e.g. <SK>^abc^123.\nA blank field e.g. aa^ ^bb, is valid to NOT get a delimiter. (default \"\"). ")
+	flag.BoolVar(&opts.Unique, "unique", false, "Each output word is sent only once (num option quantity may be reduced).\n (default false)")
+	flag.StringVar(&opts.Tutor, "tutor", d.Tutor, "Only if you use -lessons. Sets order and # of charactersby tutor type.\nChoices: (default LCWO), JustLearnMorseCode, G4FON, MorseElmer, MorseCodeNinja, HamMorse, LockdownMorse\nUse -help=tutors for more info.")
+	flag.IntVar(&opts.DM, "DM", 0, fmt.Sprintf("Delimiter multiple, (if delimiter is used.) Between 1 and DM delimiter\nstrings are concatenated. (min 0, max %d)", practice.MaxDelimChars))
+	flag.IntVar(&opts.Lesson, "lesson", 0, "Given the Koch lesson number per LCWO, populates options inlist and cglist with appropriate characters. (Default 0)")
+	flag.IntVar(&opts.Emphasis, "emphasis", 0, "With lesson, the percent of code groups and words to have one of the newest lesson characters, 0 is off.\nHow often each lesson character was sent is shown after the text.")
+	flag.IntVar(&opts.EmphasisChars, "emphasisChars", 1, "With emphasis, how many of the newest lesson characters, in the tutor's order.")
+	flag.BoolVar(&opts.DR, "DR", false, "Delimiter randomness, (if DM > 0) DR=true makes a delimiter randomly print on an instance-by-instance basis")
+	flag.IntVar(&opts.MixedMode, "mixedMode", 0, fmt.Sprintf("mixedMode X, If X gt 1 & le %d, a code group will print every X words.", practice.MaxMixedMode))
+	flag.BoolVar(&opts.Reverse, "reverse", false, "Reverses the spelling of words from inlist file (ignored for codeGroups_. (default false)")
+	flag.BoolVar(&opts.Pseudo, "pseudo", false, "Made up pronounceable words, with the character odds of the matched in file words\n(so only inlist or lesson characters), none are real words. min and max still apply.")
+	flag.IntVar(&opts.NGram, "ngram", 0, fmt.Sprintf("Drill the most frequent n-grams of this many characters (2 to %d) in the in file, like th or ing.\nOnly inlist characters are counted. (default 0, off)", practice.MaxNGram))
+	flag.IntVar(&opts.NGramTop, "ngramTop", d.NGramTop, "Number of the most frequent n-grams to drill. (default 20)")
+	flag.BoolVar(&opts.NGramGroups, "ngramGroups", false, "Put each n-gram inside a random group of cglist characters, cgmin to cgmax long. (default false)")
+	flag.IntVar(&opts.Confusable, "confusable", 0, fmt.Sprintf("Drill this many of the most alike sounding pairs (like b/6, v/4) of the cglist (code groups,\nmixedMode) or inlist (words) characters. Max %d. (default 0, off)", practice.MaxConfusable))
+	flag.BoolVar(&opts.Sentences, "sentences", false, "Whole sentences of the in file, num is the number of sentences. Punctuation in cglist is kept,\na sentence with a letter or digit not in inlist or cglist (or the lesson) is skipped. NR keeps the order.")
+	flag.BoolVar(&opts.CodeGroups, "codeGroups", false, "Random code groups from cglist characters.")
+	flag.BoolVar(&opts.Callsigns, "callsigns", false, "Random amateur callsigns (US and DX, 1x1 to 2x3, some /P /QRP /MM) made of cglist\n(or lesson) characters, instead of words from the in file.")
+	flag.BoolVar(&opts.QSO, "qso", false, fmt.Sprintf("Ragchew QSOs (CQ, calls, RST, name, QTH, rig, wx, 73 <SK>), num is the number of QSOs (max %d).", practice.MaxQSOs))
+	flag.StringVar(&opts.Contest, "contest", "", fmt.Sprintf("Contest exchanges, num is the number of exchanges. Choices: %s\n(CQ WW, CQ WPX, ARRL Sweepstakes, Field Day, NA QSO Party)", strings.Join(practice.ContestNames(), ", ")))
+	flag.BoolVar(&opts.Cut, "cut", false, "Contest cut numbers, 0 is sent as t and 9 as n, e.g. 5nn. (default false)")
+	flag.BoolVar(&opts.Radiogram, "radiogram", false, fmt.Sprintf("NTS radiograms, num is the number of messages (max %d). The text is words from the in file\n(NR keeps their order), or standard ARL and other phrases.", practice.MaxRadiograms))
+	flag.IntVar(&opts.RGWords, "rgWords", 0, fmt.Sprintf("Words of radiogram text, max %d. (default 0, 5 to 25 at random)", practice.MaxRGWords))
+	flag.StringVar(&opts.Numbers, "numbers", "", fmt.Sprintf("Formatted numbers, a comma list of: %s, or all\n(1430z, 12/25/20, 14.025, 100w, 579, 06111, fn31pr). Alone, or mixed with words by numMix.", strings.Join(practice.NumberKinds(), ", ")))
+	flag.IntVar(&opts.NumMix, "numMix", 0, fmt.Sprintf("numMix X, If X gt 1 & le %d, a number from the numbers option follows every X words.", practice.MaxMixedMode))
+	flag.BoolVar(&opts.NR, "NR", false, "Non-Randomized output words read from input.")
+	flag.BoolVar(&opts.MMR, "MMR", false, "Mixed-Mode-Random, randomizes the output of a code group in mixed mode.")
+	flag.StringVar(&opts.Cglist, "cglist", d.Cglist, "Set of characters to make random code groups.")
+	flag.StringVar(&opts.Profile, "profile", d.Profile, fmt.Sprintf("How often each cglist character is in a code group: %s\n(english letter frequency, counted in the in file, or from profileFile).", strings.Join(practice.Profiles, ", ")))
+	flag.StringVar(&opts.ProfileFile, "profileFile", "", "File of char:weight lines for profile=file, a character not listed has weight 1.")
+	flag.StringVar(&opts.Header, "header", "", "string copied verbatim to head of output")
+	flag.IntVar(&opts.EBLow, "EB_LOW", d.EBLow, "ebook2cw low character speed wpm setting.")
+	flag.IntVar(&opts.EBStep, "EB_STEP", d.EBStep, "ebook2cw wpm and/or effectie speed change increment.")
+	flag.IntVar(&opts.EBSlow, "EB_SLOW", 0, "ebook2cw number of words to send at slower speed.")
+	flag.IntVar(&opts.EBFast, "EB_FAST", 0, "ebook2cw number of words to send at faster speed.")
+	flag.IntVar(&opts.EBNum, "EB_NUM", 0, "ebook2cw number of speed change steps.")
+	flag.BoolVar(&opts.EBRamp, "EB_RAMP", false, "ebook2cw ramps speed up in steps (default false).")
+	flag.IntVar(&opts.EBRepeat, "EB_REPEAT", 0, "ebook2cw times to repeat each word with increasing speed.")
+	flag.IntVar(&opts.EBEff, "EB_EFFECTIVE", 0, "ebook2cw effective (aka Farnsworth) speed must be < EB_LOW.")
+	flag.BoolVar(&opts.EBEffRamp, "EB_EFFECTIVE_RAMP", false, "ebook2cw ramp effective speed (char speed constant) must be < EB_LOW.")
+	flag.StringVar(&opts.EBSF, "EB_SF", "", "to alert transition from EB_LOW to EB_LOW+EB_STEP for plain text in mixedMode\nor EB_SLOW text to EB_FAST text,")
+	flag.StringVar(&opts.EBFS, "EB_FS", "", "to alert transition from EB_LOW+EB_STEP speed for plain text to EB_LOW for codeGroup mixedMode\nor EB_FAST text to EB_SLOW text.")
+	flag.StringVar(&flaghelp, "help", "", "[EBOOK|INTERNATIONAL|TUTORS|EXIT] more help of given topics.")
+	flag.IntVar(&opts.WordCount, "wordCount", 0, "Number of words to link as a phrase IF <repeat> option is also used.(Max 5)")
+	flag.Int64Var(&opts.Seed, "seed", 0, "Random seed, the same seed and options give the same practice text. (default 0, a new seed each run)")
+	flag.BoolVar(&opts.SeedHeader, "seedHeader", false, "Add \"seed=N\" to the header of the output. (default false)")
+	flag.StringVar(&flagkey, "key", "", "Also write an answer key to this file name, only the text heard, as keyed in the -wav (no header or EB_ commands), numbered by line.")
+	flag.StringVar(&flagwav, "wav", "", "Also write the practice text as Morse audio to this WAV file name.")
+	flag.IntVar(&opts.WavWPM, "wavWPM", d.WavWPM, "WAV character speed in wpm, EB_ speed changes still apply. (default 20)")
+	flag.IntVar(&opts.WavEff, "wavEff", 0, "WAV effective (aka Farnsworth) speed in wpm, must be <= wavWPM. (default 0, off)")
+	flag.IntVar(&opts.WavFreq, "wavFreq", d.WavFreq, "WAV tone frequency in Hz. (default 600)")
+	flag.StringVar(&flagcopy, "copy", "", "File of what you copied, it is scored against the practice text.\nUse the same options and -seed as the session you copied.")
+	flag.BoolVar(&flagcopyTst, "copyTest", false, "Interactive copy test, requires -wav. Play the WAV, each prompt shows when its item starts,\ntype each item as you hear it, then see your score. (default false)")
+	flag.StringVar(&opts.Progress, "progress", d.Progress, "Per character progress file, each -copy or -copyTest score is added to it.\nA line is: character sent correct.")
+	flag.StringVar(&flagimport, "progressImport", "", "Results file to add to the progress file, same line format: character sent correct.")
+	flag.BoolVar(&opts.Weak, "weak", false, "Weight code group, prefix and suffix characters toward your weakest in the progress file. (default false)")
+}
+
+func main() {
+	var out io.Writer = os.Stdout
+
+	if strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe") != prog {
+		fmt.Printf("\nThe executable must be named: %s or %s\n73\nBill, WA2NFN\n", prog, program)
+		os.Exit(exitName)
+	}
+
+	flag.Parse() // first parse to see if we had -opt
+
+	if flagopt != "" {
+		_, err := os.Stat(flagopt)
+		if os.IsNotExist(err) {
+			fmt.Printf("\nError: Can't find options file=<%s>.\n", flagopt)
+			os.Exit(exitFile)
+		}
+
+		optFile, err := os.Open(flagopt)
+		if err != nil {
+			fmt.Printf("\n%s File name <%s>.\n", err, flagopt)
+			os.Exit(exitFile)
+		}
+
+		// do file parse, report every bad line at once
+		problems := doOptFile(optFile)
+		optFile.Close()
+
+		if len(problems) > 0 {
+			fmt.Printf("\nError: %d problem(s) in options file <%s>:\n", len(problems), flagopt)
+			for _, p := range problems {
+				fmt.Printf("   %s\n", p)
+			}
+			os.Exit(exitSyntax)
+		}
+
+		flag.Parse() // second parse since options read
+	}
+
+	//
+	// verify valid options
+	//
+	if flag.NArg() > 0 {
+		fmt.Printf("\nError processing the command line.\n\nYou may have:\n   forgotten a \"-\" before an option\n   or followed a \"-\" with a space\n   or added extra input\n   or put spaces arount the \"=\"\n")
+		os.Exit(exitSyntax)
+	}
+
+	if flagversion {
+		fmt.Printf("\n%s version: %s\nCopyright 2019, 2020", program, version)
+		os.Exit(exitOK)
+	}
+
+	if flaghelp != "" {
+		flaghelp = strings.ToUpper(flaghelp)
+
+		if flaghelp == "EBOOK" {
+			fmt.Println("\nEBOOK Help Info")
+			fmt.Printf("\nRelationships and compatibilities for EB_ Options\n\nOption names starting with EB_ are only for users that will input the generated practice text\nto LCWO's \"Convert text to CW\" screen, or for input to ebook2cw (see Note).\n\nOther non-EB_ options can be used as well.\n\nEB_SLOW: (alternating slow/fast word groups)\n\t\trequires: EB_SLOW, EB_LOW, EB_FAST, EB_STEP\n\t\toptional: EB_SF, EB_FS\n\t\tnon-compatible: EB_RAMP, EB_EFFECTIVE_RAMP, EB_NUM, EB_REPEAT\n")
+
+			fmt.Printf("\nEB_RAMP: (steady increase of character speed sections of words)\n\t\trequires: EB_RAMP, EB_LOW, EB_STEP, EB_NUM\n\t\toptional: EB_SF, EB_REPEAT, EB_EFFECTIVE\n\t\tnon-compatible: EB_EFFECTIVE_RAMP\n")
+
+			fmt.Printf("\nEB_EFFECTIVE_RAMP: (steady increase of effective speed sections, with fixed character speed)\n\t\trequires: EB_EFFECTIVE_RAMP, EB_LOW, EB_STEP, EB_NUM, EB_EFFECTIVE\n\t\toptional: EB_SF\n\t\tnon-compatible: EB_RAMP, EB_REPEAT\n")
+
+			fmt.Printf("\nEB_REPEAT: (each word repeated at increasing character speeds)\n\t\trequires: EB_REPEAT, EB_LOW, EB_STEP\n\t\toptional: EB_EFFECTIVE, EB_RAMP (EB_NUM if EB_RAMP)\n\t\tnon-compatible: EB_EFFECTIVE_RAMP\n")
+
+			fmt.Printf("\nEB_LOW, EB_NUM, EB_STEP (no other EB_ options): (\"bounce\" each word has random character speed)\n\t\trequires: EB_LOW, EB_STEP, EB_NUM\n\t\toptional:\n\t\tnon-compatible: EB_EFFECTIVE_RAMP, EB_RAMP, EB_REPEAT, EB_SLOW, EB_FAST, EB_SF, EB_FS\n")
+
+			fmt.Printf("\nNote: EB_SF and EB_FS MAY be used outside of LCWO/ebook2cw. If used with option mixedMode,\nthese will insert the specified string immediately before and/or after the codeGroup.\n")
+			os.Exit(exitOK)
+		} else if flaghelp == "TUTORS" {
+			fmt.Println("\nTUTORS Help Info")
+			fmt.Printf(`
+The options <tutor> and <lesson> are for user convience. By choosing the pair, you are prepopluating the
+option <inlist> which reads words from the <in> file, and <cglist> which is used to create code groups.
+
+The generated practice text therefore can be given to ANY tutor. In some cases, a tutor will teach a ProSign,
+but these two options only function with single characters.
+
+The option <inlist> will be populated with both upper and lower case for each alpha character.
+
+The term "lesson" may not be used in each tutor, but its just the order that the character was taught.
+
+Lesson is cummulative, that is if you enter lesson=5, all the characters from lesson 1 through 5 are used.
+
+Lesson  LCWO  JustLearnMorseCode  G4FON  MorseElmer  MorseCodeNinja  HamMorse  LockdownMorse
+------  ----  ------------------  -----  ----------  --------------  --------  -------------
+1       km            k           k          t          k               e           e
+2       u             m           m          m          a               m           o
+3       r             r           r          r          e               r           a
+4       e             s           s          s          n               s           i
+5       s             u           u          u          o               u           u
+6       n             a           a          a          i               a           y
+7       a             p           p          p          s               p           z
+8       P             t           t          t          l               t           q
+9       t             l           l          l          4               l           j
+10      l             o           o          o          r               o           x
+
+Lesson  LCWO  JustLearnMorseCode  G4FON  MorseElmer  MorseCodeNinja  HamMorse  LockdownMorse
+------  ----  ------------------  -----  ----------  --------------  --------  -------------
+11      w            w            w          w          h              w            k
+12      i            i            i          i          d              i            v
+13      .            .            .          .          1              .            b
+14      j            n            n          n          2              n            p
+15      z            j            j          j          5              j            + <AR> (1)
+16      = <BT>       e            e          e          c              g            g
+17      f            f            f          f          u              f            w
+18      o            0            0          0          m              0            f
+19      y            y            y          y          w              y            c
+20      ,            v            v          ,          3              ,            l
+
+Lesson  LCWO  JustLearnMorseCode  G4FON  MorseElmer  MorseCodeNinja  HamMorse  LockdownMorse
+------  ----  ------------------  -----  ----------  --------------  --------  -------------
+21      v            ,            ,          v          6              v            d
+22      g            g            g          g          ?              g            m
+23      5            5            5          5          f              5            h
+24      /            /            /          /          y              /            r
+25      q            q            q          q          p              q            s
+26      9            9            9          9          g              9            n
+27      2            z            z          z          7              z            t
+28      h            h            h          h          9              h
+29      3            3            3          3          /              3
+30      8            8            8          8          b              8
+
+Lesson  LCWO  JustLearnMorseCode  G4FON  MorseElmer  MorseCodeNinja  HamMorse LockdownMorse
+------  ----  ------------------  -----  ----------  --------------  -------- -------------
+31      b            b            b          b          v              b
+32      ?            ?            ?          ?          k              ?
+33      4            4            4          4          j              4
+34      7            2            2          2          8              2 
+35      c            7            7          7          0              7
+36      1            c            c          c          x              c
+37      d            1            1          1          q              1
+38      6            d            d          d          z              6
+39      0            6            6          6          =              x
+40      x            x            x          x          .              =
+
+Lesson  LCWO  JustLearnMorseCode  G4FON  MorseElmer  MorseCodeNinja HamMorse LockdownMorse
+------  ----  ------------------  -----  ----------  -------------- -------- -------------
+41                   @            = <BT>                + <AR> (2) 
+42                   = <BT>       + <AR> (2)
+43                   + <AR> 
+
+Notes: 
+1- <KA> also introduced but must be handled with the prosign option
+2- <SK> (same as above)
+			`) // end of table
+
+			os.Exit(exitOK)
+		} else if flaghelp == "INTERNATIONAL" {
+			fmt.Println("\nINTERNATIONAL Characters  Help Info")
+			fmt.Printf(`
+If the code tutor you intend to use knows how to send morse for international characters, you can specify them
+in the "list" options. All of the these are already included in the option <inlist>, used to find words in
+your <in> file. They are in both upper and lower case.
+
+You may include them in the following options: prelist, suflist, cglist, or delimiter. To do that, they MUST
+be entered as %%XX, as shown below. No spaces are used, and the alphas MUST be uppercase. You only need to
+inlude the leter you want in EITHER upper or lower case (cwpt2 will make the case correct based on the <caps>
+option. To include the "grave a" and "acute e" to english vowels for use as word suffixes, as an example,
+you would set the option like: suflist="aeiou%%C0%%C9".
+
+%%C0 to represent '\u00C0'  Upper Case A grave
+%%E0 to represent '\u00E0'  Lower Case a grave
+%%C4 to represent '\u00C4'  Upper Case A diaeresis
+%%E4 to represent '\u00E4'  Lower Case a diaeresis
+%%C9 to represent '\u00C9'  Upper Case E acute
+%%E9 to represent '\u00E9'  Lower Case e acute
+%%C8 to represent '\u00C8'  Upper Case E grave
+%%E8 to represent '\u00E8'  Upper Case E acute
+%%C7 to represent '\u00C7'  Upper Case C cedilla
+%%E7 to represent '\u00E7'  Lower Case c cedilla
+%%D1 to represent '\u00D1'  Upper Case N tilde
+%%F1 to represent '\u00F1'  Lower Case n tilde
+%%D6 to represent '\u00D6'  Upper Case O diaeresis
+%%F6 to represent '\u00F6'  Lower Case o diaeresis
+%%DC to represent '\u00DC'  Upper Case U diaeresis
+%%FC to represent '\u00FC'  Lower Case u diaeresis
+
+`)
+
+			os.Exit(exitOK)
+		} else if flaghelp == "EXIT" {
+			fmt.Println("\nEXIT Codes Help Info")
+			fmt.Printf(`
+cwpt2 sets an exit code, so a script or batch file can tell what happened:
+
+0  practice text was written (or version/help was shown, or you chose not to overwrite -out)
+1  the executable is not named cwpt2 or cwpt2.exe
+2  the command line or the options file could not be parsed, every bad line is listed
+3  one or more option values are out of range or conflict, every problem is listed
+4  nothing in the input file matched the options
+5  a file (in, out, opt, prosign, wav, key, copy, progress) could not be read or written
+
+`)
+			os.Exit(exitOK)
+		} else {
+			fmt.Printf("\nError: Invalid value for option <help>, choices are (case insensitive): TUTORS, EBOOK, INTERNATIONAL, or EXIT.\n")
+			os.Exit(exitSyntax)
+		}
+	}
+
+	// text piped in without an in option is read as if -in=-
+	opts.Stdin = os.Stdin
+	if opts.Input == "" && opts.ReadsInput() && !flagcopyTst && pipedStdin() {
+		opts.Input = "-"
+	}
+
+	// stdin New reads is kept when the generator is made again below
+	var stdinRead bytes.Buffer
+	if flagimport != "" && opts.Weak {
+		opts.Stdin = io.TeeReader(os.Stdin, &stdinRead)
+	}
+
+	gen := newGenerator(opts)
+
+	// only once the options are good is the import added to the progress
+	// file, -weak reads it, so the generator is made again with the import
+	if flagimport != "" {
+		importProgress(flagimport)
+
+		if opts.Weak {
+			opts.Seed = gen.Seed()
+			opts.Stdin = io.MultiReader(bytes.NewReader(stdinRead.Bytes()), os.Stdin)
+			gen = newGenerator(opts)
+		}
+	}
+
+	if flagoutput != "" {
+		fp := createFile(flagoutput)
+		defer fp.Close()
+
+		fmt.Printf("\nWriting to file: %s\n", flagoutput)
+		out = fp
+	}
+
+	text, err := gen.Generate()
+	if err != nil {
+		if errors.Is(err, practice.ErrNothingToOutput) {
+			fmt.Fprintf(os.Stderr, "\n%v\n", err)
+		} else {
+			fmt.Printf("\nError: %v.\n", err)
+		}
+		os.Exit(exitCode(err, exitFile))
+	}
+
+	// a copy test would give the answers away
+	if flagoutput != "" || (flagcopy == "" && !flagcopyTst) {
+		if _, err := io.WriteString(out, text); err != nil {
+			fmt.Printf("\nError: %v.\n", err)
+			os.Exit(exitFile)
+		}
+
+		// on stderr, like the seed, so piped or saved text stays clean
+		fmt.Fprint(os.Stderr, gen.EmphasisReport(text))
+	}
+
+	if flagkey != "" {
+		fp := createFile(flagkey)
+		defer fp.Close()
+
+		fmt.Fprintf(os.Stderr, "\nWriting answer key to file: %s\n", flagkey)
+		if _, err := io.WriteString(fp, gen.AnswerKey(text)); err != nil {
+			fmt.Printf("\nError: %v.\n", err)
+			os.Exit(exitFile)
+		}
+	}
+
+	if flagwav != "" {
+		fp := createFile(flagwav)
+		defer fp.Close()
+
+		fmt.Fprintf(os.Stderr, "\nWriting audio to file: %s\n", flagwav)
+		if err := gen.WriteWAV(fp, text); err != nil {
+			fmt.Printf("\nError: %v.\n", err)
+			os.Exit(exitFile)
+		}
+	}
+
+	var r *score.Result
+	if flagcopy != "" || flagcopyTst {
+		items := gen.CopyItems(text)
+		if flagcopy != "" {
+			r = scoreCopyFile(items, flagcopy)
+		} else {
+			r = copyTest(items, flagwav, os.Stdin)
+		}
+	}
+
+	if r != nil && opts.Progress != "" {
+		saveProgress(r)
+	}
+
+	// on stderr so piped or saved text stays clean
+	fmt.Fprintf(os.Stderr, "\nseed: %d (-seed=%d repeats this session)\n", gen.Seed(), gen.Seed())
+}
+
+// exitCode of an error from New or Generate: a file that can't be read is
+// exitFile, not a bad option value, whatever else went wrong is code
+func exitCode(err error, code int) int {
+	if errors.Is(err, practice.ErrNothingToOutput) {
+		return exitNoWords
+	}
+
+	var pathErr *fs.PathError
+	var errs practice.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			if errors.As(e, &pathErr) {
+				return exitFile
+			}
+		}
+	} else if errors.As(err, &pathErr) {
+		return exitFile
+	}
+
+	return code
+}
+
+// newGenerator checks opts, exiting with every problem found
+func newGenerator(opts practice.Options) *practice.Generator {
+	gen, err := practice.New(opts)
+	err = checkFiles(err)
+
+	if err != nil {
+		var errs practice.ValidationErrors
+		if errors.As(err, &errs) {
+			fmt.Printf("\nError: %d problem(s) with the options:\n", len(errs))
+			for _, e := range errs {
+				fmt.Printf("   %v\n", e)
+			}
+		} else {
+			fmt.Printf("\nError: %v.\n", err)
+		}
+		os.Exit(exitCode(err, exitInvalid))
+	}
+
+	return gen
+}
+
+// checkFiles adds to err any output file that would over write another file
+func checkFiles(err error) error {
+	var errs practice.ValidationErrors
+	errors.As(err, &errs)
+	n := len(errs)
+
+	if flagoutput != "" && isInput(flagoutput) {
+		errs = append(errs, &practice.FieldError{Field: "out", Value: flagoutput, Rule: "can't equal -in, or the input file would be over written"})
+	}
+
+	if flagwav != "" && (isInput(flagwav) || flagwav == flagoutput) {
+		errs = append(errs, &practice.FieldError{Field: "wav", Value: flagwav, Rule: "can't equal -in or -out, or that file would be over written"})
+	}
+
+	if flagkey != "" && (isInput(flagkey) || flagkey == flagoutput || flagkey == flagwav) {
+		errs = append(errs, &practice.FieldError{Field: "key", Value: flagkey, Rule: "can't equal -in, -out or -wav, or that file would be over written"})
+	}
+
+	if flagcopyTst && isInput("-") {
+		errs = append(errs, &practice.FieldError{Field: "copyTest", Value: flagcopyTst, Rule: "reads your copy from stdin, so the in option can't be \"-\""})
+	}
+
+	if flagcopyTst && flagwav == "" {
+		errs = append(errs, &practice.FieldError{Field: "copyTest", Value: flagcopyTst, Rule: "requires -wav, the audio you play and copy item by item"})
+	}
+
+	if flagcopy != "" && flagcopyTst {
+		errs = append(errs, &practice.FieldError{Field: "copy", Value: flagcopy, Rule: "mutually exclusive with copyTest"})
+	}
+
+	if len(errs) > n {
+		return errs
+	}
+
+	return err
+}
+
+// isInput is true if name is one of the files of the in option, or "-"
+// for stdin
+func isInput(name string) bool {
+	if name == opts.Input {
+		return true
+	}
+
+	sources, err := practice.ParseSources(opts.Input)
+	if err != nil {
+		return false
+	}
+
+	for _, src := range sources {
+		for _, file := range src.Files {
+			if filepath.Clean(file) == filepath.Clean(name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// pipedStdin is true when stdin is a pipe, as in cat novel.txt | cwpt2, not a
+// redirected file or the keyboard, those need -in=-. It doesn't read, so it
+// never waits.
+func pipedStdin() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeNamedPipe != 0
+}
+
+// createFile makes an output file, asking first if it would over write one
+func createFile(name string) *os.File {
+	// check for existance first
+	_, err := os.Stat(name)
+	if err == nil {
+		// stdin may be the words, then ask at the terminal
+		ask := io.Reader(os.Stdin)
+		if isInput("-") {
+			tty, err := os.Open("/dev/tty")
+			if err != nil {
+				fmt.Printf("\nError: out file: <%s> exists, and stdin is the in file so you can't be asked to overwrite it.\n", name)
+				os.Exit(exitFile)
+			}
+			defer tty.Close()
+			ask = tty
+		}
+
+		fmt.Printf("\nWarning: out file: <%s> exists!\n\nEnter \"y\" to overwrite it: ", name)
+		ans := ""
+		fmt.Fscanf(ask, "%s", &ans)
+		if ans != "y" {
+			fmt.Printf("\nNo output as requested.\n")
+			os.Exit(exitOK)
+		}
+	}
+
+	fp, err := os.Create(name)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(exitFile)
+	}
+
+	return fp
+}
+
+// doOptFile sets the flags from an options file, it returns a description
+// of every line it could not use so they can all be fixed at once
+func doOptFile(file *os.File) []string {
+	var problems []string
+	scanner := bufio.NewScanner(file)
+	ignore := regexp.MustCompile("^\\s*#|^\\s*$")
+	doneEnd := regexp.MustCompile("^\\s*#\\s*(END|DONE)$")
+	blkStart := regexp.MustCompile("^\\s*/\\*")
+	blkEnd := regexp.MustCompile("^\\s*\\*")
+	lineNum := 0
+	inBlk := false
+
+	for scanner.Scan() {
+		str := scanner.Text()
+		lineNum++
+
+		// start block comment
+		if inBlk == false && blkStart.MatchString(str) {
+			inBlk = true
+			continue
+		}
+
+		// end block comment
+		if inBlk == true {
+			if blkEnd.MatchString(str) {
+				inBlk = false
+			}
+			continue
+		}
+
+		if doneEnd.MatchString(str) {
+			break
+		}
+
+		if ignore.MatchString(str) {
+			continue
+		}
+
+		str = strings.TrimLeft(str, "-")
+
+		// cuts EOL comments off
+		dex := strings.Index(str, "#")
+		if dex != -1 {
+			str = str[:dex]
+		}
+
+		// we assume its an option
+		// trim down to get the string at the end
+		str = strings.TrimSpace(str)
+
+		if str == "" {
+			problems = append(problems, fmt.Sprintf("line <%d>: missing option name", lineNum))
+			continue
+		}
+
+		if str[len(str)-1] == '=' {
+			problems = append(problems, fmt.Sprintf("line <%d>: invalid format for option <%v>. Appears to be missing a value after \"=\"", lineNum, str))
+			continue
+		}
+
+		// = sep? or space sep? or just a bool
+		arr := strings.SplitN(str, "=", 2)
+		if len(arr) != 2 {
+			arr = strings.SplitN(str, " ", 2)
+		}
+
+		if flag.Lookup(arr[0]) == nil {
+			problems = append(problems, fmt.Sprintf("line <%d>: invalid option <%s>", lineNum, arr[0]))
+			continue
+		}
+
+		if arr[0] == "opt" {
+			fmt.Printf("\nWarning: option \"opt\" can't be reset in the options file <%s> on line <%d>. Ignoring it and continuing.\n", flagopt, lineNum)
+			continue
+		}
+
+		value := "true"
+		if len(arr) == 2 {
+			value = strings.TrimLeft(arr[1], "'\"")
+			value = strings.TrimRight(value, "'\"")
+		}
+
+		if err := flag.Set(arr[0], value); err != nil {
+			problems = append(problems, fmt.Sprintf("line <%d>: option <%s> value <%s>: %v", lineNum, arr[0], value, err))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,81 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wa2nfn/cwpt/practice"
+)
+
+func TestExitCode(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "words.txt", Err: fs.ErrNotExist}
+	badValue := &practice.FieldError{Field: "num", Value: 0, Rule: "minimum 1"}
+	badFile := &practice.FieldError{Field: "in", Value: "words.txt", Rule: pathErr.Error(), Err: pathErr}
+
+	tests := []struct {
+		name     string
+		err      error
+		fallback int
+		code     int
+	}{
+		{"bad value", practice.ValidationErrors{badValue}, exitInvalid, exitInvalid},
+		{"bad file", practice.ValidationErrors{badFile}, exitInvalid, exitFile},
+		{"bad value and file", practice.ValidationErrors{badValue, badFile}, exitInvalid, exitFile},
+		{"path error", fmt.Errorf("reading: %w", pathErr), exitInvalid, exitFile},
+		{"nothing to output", practice.ErrNothingToOutput, exitFile, exitNoWords},
+		{"other from New", errors.New("no"), exitInvalid, exitInvalid},
+		{"other from Generate", errors.New("no"), exitFile, exitFile},
+	}
+
+	for _, tt := range tests {
+		if code := exitCode(tt.err, tt.fallback); code != tt.code {
+			t.Errorf("%s: exitCode = %d, want %d", tt.name, code, tt.code)
+		}
+	}
+}
+
+func TestPipedStdin(t *testing.T) {
+	stdin := os.Stdin
+	defer func() { os.Stdin = stdin }()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	file := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(file, []byte("apple"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		name  string
+		stdin *os.File
+		piped bool
+	}{
+		{"pipe", r, true},
+		{"redirected file", f, false},
+	}
+
+	for _, tt := range tests {
+		os.Stdin = tt.stdin
+		if piped := pipedStdin(); piped != tt.piped {
+			t.Errorf("%s: pipedStdin = %v, want %v", tt.name, piped, tt.piped)
+		}
+	}
+}
@@ -7,7 +7,6 @@
 package main
 
 import (
-	"bufio"
 	"flag"
 	"fmt"
 	"math/rand"
@@ -16,6 +15,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/wa2nfn/cwpt/koch"
 )
 
 const (
@@ -59,7 +60,7 @@ func init() {
 	flag.BoolVar(&flagsingleword, "single", false, "Display single string per line (default false)")
 	flag.StringVar(&flaginput, "in", "in.txt", "Input text file name (including extension).")
 	flag.StringVar(&flagoutput, "out", "", "Output file name.")
-	flag.StringVar(&flagtutor, "tutor", "LCWO", "Only if you use -lessons. Sets order and # of charactersby tutor type.\nChoices: (default LCWO), JustLearnMorseCode, G4FON, MorseElmer")
+	flag.StringVar(&flagtutor, "tutor", "LCWO", "Only if you use -lessons. Sets order and # of charactersby tutor type.\nChoices: (default LCWO), "+tutorNames())
 
 	flag.IntVar(&flaglesson, "lesson", 0, "Given the Koch lesson number per LCWO, populates options inlist and cglist with appropriate characters. (Default 0)")
 	flag.StringVar(&flaginlist, "inlist", "A-Za-z", "Set of characters to define an input word.")
@@ -69,7 +70,7 @@ func init() {
 func main() {
 	flag.Usage = func() {
 
-	const text =`
+		text := `
 
 random.exe - randomize strings in a file for a morse tutor, sending practice, typing practice, etc.
 
@@ -84,7 +85,7 @@ random.exe - randomize strings in a file for a morse tutor, sending practice, ty
 	 -single or -numPerLine the number of strings to print per line (if line length (len) is not exceeded)
 	 -len number of characters in the output line length
 	 -lesson X, where X is the lesson number for your tutor (default=0)
-	 -tutor X, where X is:  LCWO, JustLearnMorseCode, G4FON, MorseElmer (default=LCWO)
+	 -tutor X, where X is (not case sensitive):  ` + tutorNames() + ` (default=LCWO)
 	 -numPerLine number of strings to print on output (if line length (len) is not exceeded)
 	 -delimiter a string of characters to delimit output strings (default a space)
 	 -version software version
@@ -101,7 +102,6 @@ random.exe - randomize strings in a file for a morse tutor, sending practice, ty
 		os.Exit(0)
 	}
 
-	kochChars := "kmuresnaptlwi.jz=foy,vg5/q92h38b?47c1d60x" // default for LCWO
 	var fp *os.File
 
 	flag.Parse()
@@ -178,64 +178,26 @@ random.exe - randomize strings in a file for a morse tutor, sending practice, ty
 		fmt.Printf("\n*** Writing to file: %s\n", flagoutput)
 	}
 
-	s := 0
-
-	if flaglesson == 0 && flagtutor != "LCWO" {
+	if flaglesson == 0 && !strings.EqualFold(flagtutor, "LCWO") {
 		fmt.Printf("\nError: Lesson = 0 is invalid for tutor <%s>.\n", flagtutor)
 		os.Exit(1)
 	}
 
 	if flaglesson >= 1 {
-
-		if flagtutor == "LCWO" {
-			kochChars = "kmuresnaptlwi.jz=foy,vg5/q92h38b?47c1d60x"
-		} else if flagtutor == "JustLearnMorseCode" {
-			kochChars = "kmrsuaptlowi.njef0yv,g5/q9zh38b?427c1d6x@=+"
-			s = 0
-		} else if flagtutor == "G4FON" {
-			kochChars = "kmrsuaptlowi.njef0yv,g5/q9zh38b?427c1d6x"
-			s = 0
-		} else if flagtutor == "MorseElmer" {
-			kochChars = "kmrsuaptlowi.njef0y,vg5/q9zh38b?427c1d6x="
-			s = 0
-		} else {
-			fmt.Printf("\nError: Your tutor name is invalid. Names are case sensitive and without any spaces, see the help.\n")
+		tutor, ok := koch.FindTutor(flagtutor)
+		if !ok {
+			fmt.Printf("\nError: Your tutor name is invalid. Names are NOT case sensitive, and without any spaces, see the help.\n")
 			os.Exit(1)
 		}
 
-		if (flaglesson+1 > len(kochChars)) && flagtutor == "LCWO" {
-			fmt.Printf("\nError: Lesson value <%d> exceeds the max <%d>, for tutor <%s>.\n", flaglesson, 40, flagtutor)
-			os.Exit(1)
-		}
-
-		if flaglesson > len(kochChars) {
-			fmt.Printf("\nError: Lesson value <%d> exceeds the max <%d>, for tutor <%s>.\n", flaglesson, len(kochChars), flagtutor)
+		chars, err := tutor.Lesson(flaglesson)
+		if err != nil {
+			fmt.Printf("\nError: Lesson value <%d> %v.\n", flaglesson, err)
 			os.Exit(1)
 		}
 
-		if flagtutor == "LCWO" {
-			if flaglesson < len(kochChars) {
-				flaginlist = kochChars[0 : flaglesson+1]
-			} else {
-				flaginlist = kochChars[0:flaglesson]
-			}
-		} else {
-			flaginlist = kochChars[s:flaglesson]
-		}
-
-		// now build inlist initailly as lower case
-		// now inlist is LC
-		temp := strings.ToUpper(flaginlist)
-
-		// now add upper case
-		for _, char := range temp {
-			if char >= 'A' && char <= 'Z' {
-				flaginlist += string(char)
-			}
-		}
-		// now inlist is mixed case
-		temp = ""
-
+		// inlist is mixed case
+		flaginlist = koch.Inlist(chars)
 	}
 
 	readFileMode(fp)
@@ -253,16 +215,11 @@ func readFileMode(fp *os.File) {
 	defer file.Close()
 	word := regexp.MustCompile(fmt.Sprintf(`^[%s]{%d,%d}\s*$`, flaginlist, flagmin, flagmax))
 
-	scanner := bufio.NewScanner(file)
+	scanner := koch.NewScanner(file)
 
 	for scanner.Scan() {
 		// first way to split the string on spaces
-		textWords := strings.FieldsFunc(scanner.Text(), func(r rune) bool {
-			if r == ' ' {
-				return true
-			}
-			return false
-		})
+		textWords := koch.Words(scanner.Text())
 
 		for index := 0; index < len(textWords); index++ {
 			// every token is now a string of space separated characters
@@ -402,3 +359,14 @@ func outPut(fp *os.File) {
 		}
 	}
 }
+
+// the tutor names for the help
+func tutorNames() string {
+	names := []string{}
+
+	for _, t := range koch.Tutors {
+		names = append(names, t.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
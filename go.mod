@@ -0,0 +1,3 @@
+module github.com/wa2nfn/cwpt
+
+go 1.16
@@ -0,0 +1,8 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+// Package koch holds what cwpt2 and random share: the order each code tutor
+// teaches its characters, parsing of the character list options, and
+// splitting input text into words.
+package koch
@@ -0,0 +1,86 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package koch
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var percentCode = regexp.MustCompile("%[C-F][0146789C]")
+
+// ExpandRanges walks a list option to expand any char ranges, "a-e" gives "abcde".
+func ExpandRanges(inStr string) (string, error) {
+	outStr := ""
+	last := ""
+	gotDash := false
+
+	if inStr == "" {
+		return "", nil
+	}
+
+	// take care of special case of dash in beginning
+	if inStr[0] == '-' {
+		return "", errors.New("\"-\" character(s) at start of list option")
+	}
+
+	for _, char := range strings.Split(inStr, "") {
+
+		if char == "-" {
+			if gotDash == true {
+				return "", errors.New("sequencial \"-\" characters in a list option")
+			}
+
+			gotDash = true
+			continue
+		}
+
+		if gotDash == false {
+			last = char
+			outStr += last
+		} else {
+			s, err := ExpandRange(last, char)
+			if err != nil {
+				return "", err
+			}
+			outStr += string([]rune(s)[1:]) // we did low already
+			gotDash = false
+			last = ""
+		}
+	}
+
+	//  detect error
+	if gotDash && len(inStr) > 1 {
+		return "", errors.New("trailing \"-\" characters in a list option")
+	}
+
+	return outStr, nil
+}
+
+// ExpandRange expands a char range into the individual chars, lower and upper included.
+func ExpandRange(lower string, upper string) (string, error) {
+	outStr := ""
+
+	low := rune(lower[0])
+	up := rune(upper[0])
+
+	if up < low {
+		return "", errors.New("range is not in ASCII/UTF-8 order: i.e. C-A (invalid) vs. A-C (correct), " +
+			"delimiters support ONLY a single range in a field. i.e. ^[A-D]^ or ^[0-3]^")
+	}
+
+	for i := low; i <= up; i++ {
+		outStr += string(i)
+	}
+	return outStr, nil
+}
+
+// ExpandPercent replaces any %XX international codes in a list with the actual character.
+func ExpandPercent(list string) string {
+	return percentCode.ReplaceAllStringFunc(list, func(s string) string {
+		return string(International(strings.TrimLeft(s, "%")))
+	})
+}
@@ -0,0 +1,150 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package koch
+
+var (
+	// fill the rune map which is used to validate option string like: cglist, prelist, delimiter
+	runeMap    = make(map[rune]struct{})
+	runeMapInt = make(map[string]rune)
+)
+
+// runes only allowed in a delimiter field, in addition to runeMap
+var delimiterRunes = map[rune]struct{}{
+	'<': struct{}{},
+	'>': struct{}{},
+	' ': struct{}{},
+}
+
+// Valid reports if r can be sent, so may be used in a list option
+// like prelist, suflist, cglist or delimiter.
+func Valid(r rune) bool {
+	_, ok := runeMap[r]
+	return ok
+}
+
+// DelimiterOnly reports if r is only valid in a delimiter (prosigns and space).
+func DelimiterOnly(r rune) bool {
+	_, ok := delimiterRunes[r]
+	return ok
+}
+
+// International returns the character for a %XX code given without the %,
+// e.g. "C9" for E acute, or 0 if there is none.
+func International(code string) rune {
+	return runeMapInt[code]
+}
+
+func init() {
+	runeMap['a'] = struct{}{}
+	runeMap['b'] = struct{}{}
+	runeMap['c'] = struct{}{}
+	runeMap['d'] = struct{}{}
+	runeMap['e'] = struct{}{}
+	runeMap['f'] = struct{}{}
+	runeMap['g'] = struct{}{}
+	runeMap['h'] = struct{}{}
+	runeMap['i'] = struct{}{}
+	runeMap['j'] = struct{}{}
+	runeMap['k'] = struct{}{}
+	runeMap['l'] = struct{}{}
+	runeMap['m'] = struct{}{}
+	runeMap['n'] = struct{}{}
+	runeMap['o'] = struct{}{}
+	runeMap['p'] = struct{}{}
+	runeMap['q'] = struct{}{}
+	runeMap['r'] = struct{}{}
+	runeMap['s'] = struct{}{}
+	runeMap['t'] = struct{}{}
+	runeMap['u'] = struct{}{}
+	runeMap['v'] = struct{}{}
+	runeMap['w'] = struct{}{}
+	runeMap['x'] = struct{}{}
+	runeMap['y'] = struct{}{}
+	runeMap['z'] = struct{}{}
+	runeMap['A'] = struct{}{}
+	runeMap['B'] = struct{}{}
+	runeMap['C'] = struct{}{}
+	runeMap['D'] = struct{}{}
+	runeMap['E'] = struct{}{}
+	runeMap['F'] = struct{}{}
+	runeMap['G'] = struct{}{}
+	runeMap['H'] = struct{}{}
+	runeMap['I'] = struct{}{}
+	runeMap['J'] = struct{}{}
+	runeMap['K'] = struct{}{}
+	runeMap['L'] = struct{}{}
+	runeMap['M'] = struct{}{}
+	runeMap['N'] = struct{}{}
+	runeMap['O'] = struct{}{}
+	runeMap['P'] = struct{}{}
+	runeMap['Q'] = struct{}{}
+	runeMap['R'] = struct{}{}
+	runeMap['S'] = struct{}{}
+	runeMap['T'] = struct{}{}
+	runeMap['U'] = struct{}{}
+	runeMap['V'] = struct{}{}
+	runeMap['W'] = struct{}{}
+	runeMap['X'] = struct{}{}
+	runeMap['Y'] = struct{}{}
+	runeMap['Z'] = struct{}{}
+	runeMap['0'] = struct{}{}
+	runeMap['1'] = struct{}{}
+	runeMap['2'] = struct{}{}
+	runeMap['3'] = struct{}{}
+	runeMap['4'] = struct{}{}
+	runeMap['5'] = struct{}{}
+	runeMap['6'] = struct{}{}
+	runeMap['7'] = struct{}{}
+	runeMap['8'] = struct{}{}
+	runeMap['9'] = struct{}{}
+	runeMap[','] = struct{}{}
+	runeMap['.'] = struct{}{}
+	runeMap['/'] = struct{}{}
+	runeMap['?'] = struct{}{}
+	runeMap['='] = struct{}{}
+	runeMap['+'] = struct{}{}
+	runeMap['!'] = struct{}{}      // added at bottom of LCWO
+	runeMap['"'] = struct{}{}      // added at bottom of LCWO
+	runeMap['\''] = struct{}{}     // added at bottom of LCWO
+	runeMap['('] = struct{}{}      // added at bottom of LCWO
+	runeMap[')'] = struct{}{}      // added at bottom of LCWO
+	runeMap['-'] = struct{}{}      // added at bottom of LCWO
+	runeMap[':'] = struct{}{}      // added at bottom of LCWO
+	runeMap[';'] = struct{}{}      // added at bottom of LCWO
+	runeMap['\u00C0'] = struct{}{} // cap A grave
+	runeMap['\u00E0'] = struct{}{} // low a grave
+	runeMap['\u00C4'] = struct{}{} // cap A diaeresis
+	runeMap['\u00E4'] = struct{}{} // low a diaeresis
+	runeMap['\u00C9'] = struct{}{} // cap E acute
+	runeMap['\u00E9'] = struct{}{} // low e acute
+	runeMap['\u00C8'] = struct{}{} // cap E grave
+	runeMap['\u00E8'] = struct{}{} // cap E acute
+	runeMap['\u00C7'] = struct{}{} // cap C cedilla
+	runeMap['\u00E7'] = struct{}{} // low c cedilla
+	runeMap['\u00D1'] = struct{}{} // cap N tilde
+	runeMap['\u00F1'] = struct{}{} // low n tilde
+	runeMap['\u00D6'] = struct{}{} // cap O diaeresis
+	runeMap['\u00F6'] = struct{}{} // low o diaeresis
+	runeMap['\u00DC'] = struct{}{} // cap U diaeresis
+	runeMap['\u00FC'] = struct{}{} // low u diaeresis
+	runeMap['*'] = struct{}{}      // DUMMY value for delimiter and ebook users
+
+	runeMapInt["C0"] = '\u00C0' // cap A grave
+	runeMapInt["E0"] = '\u00E0' // low a grave
+	runeMapInt["C4"] = '\u00C4' // cap A diaeresis
+	runeMapInt["E4"] = '\u00E4' // low a diaeresis
+	runeMapInt["C9"] = '\u00C9' // cap E acute
+	runeMapInt["E9"] = '\u00E9' // low e acute
+	runeMapInt["C8"] = '\u00C8' // cap E grave
+	runeMapInt["E8"] = '\u00E8' // cap E acute
+	runeMapInt["C7"] = '\u00C7' // cap C cedilla
+	runeMapInt["E7"] = '\u00E7' // low c cedilla
+	runeMapInt["D1"] = '\u00D1' // cap N tilde
+	runeMapInt["F1"] = '\u00F1' // low n tilde
+	runeMapInt["D6"] = '\u00D6' // cap O diaeresis
+	runeMapInt["F6"] = '\u00F6' // low o diaeresis
+	runeMapInt["DC"] = '\u00DC' // cap U diaeresis
+	runeMapInt["FC"] = '\u00FC' // low u diaeresis
+}
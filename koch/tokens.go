@@ -0,0 +1,38 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package koch
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// a whole paragraph may be one line in a book
+const maxLine = 1024 * 1024
+
+// NewScanner returns a line scanner for input text.
+func NewScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLine)
+
+	return scanner
+}
+
+// Words splits a line of text on spaces.
+func Words(line string) []string {
+	return strings.FieldsFunc(line, func(r rune) bool {
+		if r == ' ' {
+			return true
+		}
+		return false
+	})
+}
+
+// TrimWord strips the punctuation ending a sentence and any quotes around a word.
+func TrimWord(word string) string {
+	word = strings.TrimRight(word, ".\",?!")
+	return strings.TrimLeft(word, "\"")
+}
@@ -0,0 +1,71 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package koch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tutor is a code tutor and the order it teaches characters.
+type Tutor struct {
+	Name  string // as shown in help, matched case insensitive
+	Chars string // lower case, in the order taught
+	First int    // characters taught in lesson 1
+}
+
+// Tutors in the order they are listed in the help
+var Tutors = []Tutor{
+	{"LCWO", "kmuresnaptlwi.jz=foy,vg5/q92h38b?47c1d60x", 2},
+	{"JustLearnMorseCode", "kmrsuaptlowi.njef0yv,g5/q9zh38b?427c1d6x@=+", 1},
+	{"G4FON", "kmrsuaptlowi.njef0yv,g5/q9zh38b?427c1d6x", 1},
+	{"MorseElmer", "kmrsuaptlowi.njef0y,vg5/q9zh38b?427c1d6x=+", 1},
+	{"MorseCodeNinja", "taenois14rhdl25cumw36?fypg79/bvkj80xqz=.", 1},
+	{"HamMorse", "kmrsuaptlowi.njef0y,vg5/q9zh38b?427c1d6x=+", 1},
+	{"LockdownMorse", "eoaiuyzqjxkvbp+gwfcldmhrsnt", 1},
+}
+
+// FindTutor returns the tutor by name, names are NOT case sensitive.
+func FindTutor(name string) (Tutor, bool) {
+	for _, t := range Tutors {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+
+	return Tutor{}, false
+}
+
+// Lessons is the number of the last lesson.
+func (t Tutor) Lessons() int {
+	return len(t.Chars) - t.First + 1
+}
+
+// Lesson returns every character learned from lesson 1 through lesson n.
+func (t Tutor) Lesson(n int) (string, error) {
+	if n < 1 {
+		return "", fmt.Errorf("lesson must be >= 1")
+	}
+
+	if n > t.Lessons() {
+		return "", fmt.Errorf("exceeds the max <%d>, for tutor <%s>", t.Lessons(), t.Name)
+	}
+
+	return t.Chars[:n+t.First-1], nil
+}
+
+// Inlist returns chars with the upper case of each letter added, to
+// match words in either case.
+func Inlist(chars string) string {
+	inlist := chars
+
+	for _, char := range strings.ToUpper(chars) {
+		if char >= 'A' && char <= 'Z' {
+			inlist += string(char)
+		}
+	}
+
+	return inlist
+}
@@ -0,0 +1,104 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+// Package morse knows the dits and dahs of each character, and can turn
+// practice text (with its ebook2cw speed commands) into sound.
+package morse
+
+import (
+	"strings"
+	"unicode"
+)
+
+// codes, "." is a dit "-" is a dah
+var codes = map[rune]string{
+	'a':  ".-",
+	'b':  "-...",
+	'c':  "-.-.",
+	'd':  "-..",
+	'e':  ".",
+	'f':  "..-.",
+	'g':  "--.",
+	'h':  "....",
+	'i':  "..",
+	'j':  ".---",
+	'k':  "-.-",
+	'l':  ".-..",
+	'm':  "--",
+	'n':  "-.",
+	'o':  "---",
+	'p':  ".--.",
+	'q':  "--.-",
+	'r':  ".-.",
+	's':  "...",
+	't':  "-",
+	'u':  "..-",
+	'v':  "...-",
+	'w':  ".--",
+	'x':  "-..-",
+	'y':  "-.--",
+	'z':  "--..",
+	'0':  "-----",
+	'1':  ".----",
+	'2':  "..---",
+	'3':  "...--",
+	'4':  "....-",
+	'5':  ".....",
+	'6':  "-....",
+	'7':  "--...",
+	'8':  "---..",
+	'9':  "----.",
+	'.':  ".-.-.-",
+	',':  "--..--",
+	'?':  "..--..",
+	'/':  "-..-.",
+	'=':  "-...-",
+	'+':  ".-.-.",
+	'!':  "-.-.--",
+	'"':  ".-..-.",
+	'\'': ".----.",
+	'(':  "-.--.",
+	')':  "-.--.-",
+	'-':  "-....-",
+	':':  "---...",
+	';':  "-.-.-.",
+	'@':  ".--.-.",
+	'&':  ".-...",
+	'$':  "...-..-",
+	'à':  ".--.-", // low a grave
+	'ä':  ".-.-",  // low a diaeresis
+	'é':  "..-..", // low e acute
+	'è':  ".-..-", // low e grave
+	'ç':  "-.-..", // low c cedilla
+	'ñ':  "--.--", // low n tilde
+	'ö':  "---.",  // low o diaeresis
+	'ü':  "..--",  // low u diaeresis
+}
+
+// Code returns the dits and dahs for r, either case.
+func Code(r rune) (string, bool) {
+	c, ok := codes[unicode.ToLower(r)]
+	return c, ok
+}
+
+// Prosign returns the code of a prosign like "<BT>", its letters sent
+// without a gap between them.
+func Prosign(ps string) (string, bool) {
+	letters := strings.TrimSuffix(strings.TrimPrefix(ps, "<"), ">")
+	code := ""
+
+	if letters == "" {
+		return "", false
+	}
+
+	for _, r := range letters {
+		c, ok := Code(r)
+		if !ok {
+			return "", false
+		}
+		code += c
+	}
+
+	return code, true
+}
@@ -0,0 +1,47 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package morse
+
+// Dits returns how long word takes to send in dit units: a dit is 1, a dah
+// 3, with 1 between the elements of a character and 3 between characters.
+// A prosign like <BT> is one character. ok is false if a character has no code.
+func Dits(word string) (int, bool) {
+	dits := 0
+	ok := true
+
+	for i, char := range Chars(word) {
+		var code string
+		var found bool
+
+		if runes := []rune(char); len(runes) > 1 {
+			code, found = Prosign(char)
+		} else {
+			code, found = Code(runes[0])
+		}
+
+		if !found {
+			ok = false
+			continue
+		}
+
+		if i > 0 {
+			dits += 3
+		}
+
+		for j, e := range code {
+			if j > 0 {
+				dits++
+			}
+
+			if e == '-' {
+				dits += 3
+			} else {
+				dits++
+			}
+		}
+	}
+
+	return dits, ok
+}
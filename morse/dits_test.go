@@ -0,0 +1,33 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package morse
+
+import "testing"
+
+func TestDits(t *testing.T) {
+	tests := []struct {
+		word string
+		dits int
+		ok   bool
+	}{
+		{"e", 1, true},
+		{"t", 3, true},
+		{"ee", 5, true},
+		{"eieie", 21, true},
+		{"qjyqj", 77, true},
+		{"E", 1, true},
+		{"<BT>", 13, true},
+		{"e<ar>", 17, true},
+		{"", 0, true},
+		{"e~e", 5, false},
+	}
+
+	for _, tt := range tests {
+		dits, ok := Dits(tt.word)
+		if dits != tt.dits || ok != tt.ok {
+			t.Errorf("Dits(%q) = %d, %v, want %d, %v", tt.word, dits, ok, tt.dits, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package morse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Kind of a Token in practice text
+type Kind int
+
+const (
+	Word      Kind = iota // text to send
+	Speed                 // |wN character speed in wpm
+	Effective             // |eN effective (aka Farnsworth) speed in wpm
+	Silence               // |SN silence in milliseconds
+	Tone                  // |fN tone in Hz
+)
+
+// Token is a word of practice text, or one of the ebook2cw commands
+// cwpt2 writes for the EB_ options.
+type Token struct {
+	Kind  Kind
+	Text  string // as written
+	Value int    // speed or milliseconds, for a command
+}
+
+// Parse splits practice text into words and ebook2cw commands.
+func Parse(text string) []Token {
+	tokens := []Token{}
+
+	for _, field := range strings.Fields(text) {
+		// a command can follow a word with no space, like "<BT>|e13"
+		for len(field) > 0 {
+			end := strings.Index(field[1:], "|") + 1
+			if end == 0 {
+				end = len(field)
+			}
+
+			tokens = append(tokens, parseOne(field[:end]))
+			field = field[end:]
+		}
+	}
+
+	return tokens
+}
+
+// one word, or a command
+func parseOne(s string) Token {
+	if len(s) > 2 && s[0] == '|' {
+		if n, err := strconv.Atoi(s[2:]); err == nil {
+			switch s[1] {
+			case 'w':
+				return Token{Kind: Speed, Text: s, Value: n}
+			case 'e':
+				return Token{Kind: Effective, Text: s, Value: n}
+			case 'S':
+				return Token{Kind: Silence, Text: s, Value: n}
+			case 'f':
+				return Token{Kind: Tone, Text: s, Value: n}
+			}
+		}
+	}
+
+	return Token{Kind: Word, Text: s}
+}
+
+// Chars splits a word into what is sent as one character, a prosign
+// like <BT> is a single character.
+func Chars(word string) []string {
+	chars := []string{}
+	runes := []rune(word)
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '<' {
+			if end := strings.IndexRune(string(runes[i:]), '>'); end > 0 {
+				ps := string(runes[i:])[:end+1]
+				chars = append(chars, ps)
+				i += len([]rune(ps)) - 1
+				continue
+			}
+		}
+
+		chars = append(chars, string(runes[i]))
+	}
+
+	return chars
+}
+
+// Plain returns only the text that is heard, the ebook2cw commands removed
+// and words separated by a single space.
+func Plain(text string) string {
+	return strings.Join(Words(text), " ")
+}
+
+// Words returns only the words that are heard.
+func Words(text string) []string {
+	words := []string{}
+
+	for _, t := range Parse(text) {
+		if t.Kind == Word {
+			words = append(words, t.Text)
+		}
+	}
+
+	return words
+}
@@ -0,0 +1,86 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package morse
+
+import "sort"
+
+// Similarity of the codes of a and b, 1 is the same code and 0 nothing alike.
+// It is the edit distance of the dits and dahs, over the longer code.
+func Similarity(a rune, b rune) float64 {
+	ca, ok1 := Code(a)
+	cb, ok2 := Code(b)
+	if !ok1 || !ok2 {
+		return 0
+	}
+
+	longer := len(ca)
+	if len(cb) > longer {
+		longer = len(cb)
+	}
+
+	return 1 - float64(distance(ca, cb))/float64(longer)
+}
+
+// Pair of characters, easily confused if Score is near 1
+type Pair struct {
+	A, B  rune
+	Score float64
+}
+
+// Pairs returns every pair of chars that have a code, most similar first.
+func Pairs(chars []rune) []Pair {
+	pairs := []Pair{}
+
+	for i, a := range chars {
+		for _, b := range chars[i+1:] {
+			if s := Similarity(a, b); s > 0 && a != b {
+				pairs = append(pairs, Pair{A: a, B: b, Score: s})
+			}
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Score > pairs[j].Score
+	})
+
+	return pairs
+}
+
+// edit distance of two codes
+func distance(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
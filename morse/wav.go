@@ -0,0 +1,250 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package morse
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strings"
+)
+
+// Sound sets how practice text is keyed.
+type Sound struct {
+	WPM        int // character speed, until the text has a |w
+	Effective  int // effective (aka Farnsworth) speed, until the text has a |e, 0 is off
+	Freq       int // tone in Hz
+	SampleRate int // samples per second
+}
+
+// DefaultSound is 20 wpm with a 600 Hz tone.
+func DefaultSound() Sound {
+	return Sound{WPM: 20, Freq: 600, SampleRate: 22050}
+}
+
+const (
+	amplitude = 0.5 * math.MaxInt16
+	rampTime  = 0.005 // seconds to key up and down, without it you hear clicks
+)
+
+// ErrWAVTooLong is returned for a session over the 4 GiB a WAV file can hold.
+var ErrWAVTooLong = errors.New("the audio is too long for a WAV file, use fewer words or a faster speed")
+
+// keyer makes the samples, only counting them if out is nil
+type keyer struct {
+	sound   Sound
+	wpm     int
+	eff     int
+	samples int64
+	out     *bufio.Writer
+	err     error
+}
+
+// WriteWAV writes text as a 16 bit mono PCM WAV file. The ebook2cw commands
+// in the text (|w, |e, |f and |S) are honored just as ebook2cw would. The text
+// is keyed twice, first to size the header, then to stream the samples, so
+// a long session isn't held in memory.
+func WriteWAV(w io.Writer, text string, s Sound) error {
+	tokens := Parse(text)
+
+	count := &keyer{sound: s, wpm: s.WPM, eff: s.Effective}
+	count.key(tokens)
+
+	dataLen := count.samples * 2
+	if 36+dataLen > math.MaxUint32 {
+		return ErrWAVTooLong
+	}
+
+	k := &keyer{sound: s, wpm: s.WPM, eff: s.Effective, out: bufio.NewWriter(w)}
+	if err := k.header(uint32(dataLen)); err != nil {
+		return err
+	}
+
+	k.key(tokens)
+	if k.err != nil {
+		return k.err
+	}
+
+	return k.out.Flush()
+}
+
+// WordTimes returns when each word of text starts, in seconds, in the audio
+// WriteWAV makes of it. There is a time for each word of Words(text).
+func WordTimes(text string, s Sound) []float64 {
+	k := &keyer{sound: s, wpm: s.WPM, eff: s.Effective}
+	times := []float64{}
+
+	for _, t := range Parse(text) {
+		if t.Kind == Word {
+			times = append(times, float64(k.samples)/float64(s.SampleRate))
+		}
+		k.key([]Token{t})
+	}
+
+	return times
+}
+
+// key each token of the text
+func (k *keyer) key(tokens []Token) {
+	for _, t := range tokens {
+		switch t.Kind {
+		case Speed:
+			k.wpm = t.Value
+		case Effective:
+			k.eff = t.Value
+		case Silence:
+			k.silence(float64(t.Value) / 1000)
+		case Tone:
+			k.sound.Freq = t.Value
+		case Word:
+			k.word(t.Text)
+		}
+	}
+}
+
+// length of a dit in seconds, PARIS timing
+func (k *keyer) dit() float64 {
+	return 1.2 / float64(k.wpm)
+}
+
+// gaps between characters and words, stretched if effective speed is set
+func (k *keyer) gaps() (float64, float64) {
+	c := float64(k.wpm)
+	s := float64(k.eff)
+
+	if k.eff <= 0 || k.eff >= k.wpm {
+		return 3 * k.dit(), 7 * k.dit()
+	}
+
+	// ARRL's Farnsworth timing, total delay added to a standard word
+	ta := (60*c - 37.2*s) / (s * c)
+
+	return 3 * ta / 19, 7 * ta / 19
+}
+
+// send a word, a character we don't have a code for is skipped
+func (k *keyer) word(word string) {
+	charGap, wordGap := k.gaps()
+	sent := false
+
+	for _, char := range Chars(word) {
+		code, ok := Code([]rune(char)[0])
+		if strings.HasPrefix(char, "<") {
+			code, ok = Prosign(char)
+		}
+
+		if !ok {
+			continue
+		}
+
+		if sent {
+			k.silence(charGap)
+		}
+
+		k.send(code)
+		sent = true
+	}
+
+	if sent {
+		k.silence(wordGap)
+	}
+}
+
+// send the dits and dahs of one character
+func (k *keyer) send(code string) {
+	for i, e := range code {
+		if i > 0 {
+			k.silence(k.dit())
+		}
+
+		if e == '-' {
+			k.tone(3 * k.dit())
+		} else {
+			k.tone(k.dit())
+		}
+	}
+}
+
+func (k *keyer) tone(secs float64) {
+	rate := float64(k.sound.SampleRate)
+	n := int(secs * rate)
+	ramp := int(rampTime * rate)
+
+	if k.out == nil {
+		k.samples += int64(n)
+		return
+	}
+
+	if ramp > n/2 {
+		ramp = n / 2
+	}
+
+	for i := 0; i < n; i++ {
+		env := 1.0
+		if i < ramp {
+			env = float64(i) / float64(ramp)
+		} else if n-i < ramp {
+			env = float64(n-i) / float64(ramp)
+		}
+
+		v := amplitude * env * math.Sin(2*math.Pi*float64(k.sound.Freq)*float64(i)/rate)
+		k.sample(int16(v))
+	}
+}
+
+func (k *keyer) silence(secs float64) {
+	n := int(secs * float64(k.sound.SampleRate))
+
+	if k.out == nil {
+		k.samples += int64(n)
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		k.sample(0)
+	}
+}
+
+// sample counts, and writes if there is an out, one sample
+func (k *keyer) sample(v int16) {
+	k.samples++
+
+	if k.out == nil || k.err != nil {
+		return
+	}
+
+	_, k.err = k.out.Write([]byte{byte(v), byte(uint16(v) >> 8)})
+}
+
+// write the RIFF header for dataLen bytes of samples
+func (k *keyer) header(dataLen uint32) error {
+	rate := uint32(k.sound.SampleRate)
+
+	header := []interface{}{
+		[4]byte{'R', 'I', 'F', 'F'},
+		36 + dataLen,
+		[4]byte{'W', 'A', 'V', 'E'},
+		[4]byte{'f', 'm', 't', ' '},
+		uint32(16), // fmt chunk size
+		uint16(1),  // PCM
+		uint16(1),  // mono
+		rate,
+		rate * 2,   // byte rate
+		uint16(2),  // block align
+		uint16(16), // bits per sample
+		[4]byte{'d', 'a', 't', 'a'},
+		dataLen,
+	}
+
+	for _, v := range header {
+		if err := binary.Write(k.out, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
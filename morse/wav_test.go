@@ -0,0 +1,99 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package morse
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestWriteWAV(t *testing.T) {
+	s := DefaultSound()
+
+	tests := []struct {
+		text string
+		secs float64 // about, each element is rounded down to a sample
+	}{
+		{"paris", 3.0}, // 50 dits at 20 wpm
+		{"paris paris", 6.0},
+		{"|w10 paris", 6.0},
+		{"paris |S500", 3.5},
+		{"|f800 paris", 3.0},
+		{"~", 0}, // no code, no sound
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := WriteWAV(&buf, tt.text, s); err != nil {
+			t.Errorf("WriteWAV(%q): %v", tt.text, err)
+			continue
+		}
+
+		b := buf.Bytes()
+		if len(b) < 44 || string(b[0:4]) != "RIFF" || string(b[8:12]) != "WAVE" || string(b[36:40]) != "data" {
+			t.Errorf("WriteWAV(%q): not a WAV header % x", tt.text, b[:44])
+			continue
+		}
+
+		riffLen := binary.LittleEndian.Uint32(b[4:8])
+		dataLen := binary.LittleEndian.Uint32(b[40:44])
+		if int(riffLen) != len(b)-8 || int(dataLen) != len(b)-44 {
+			t.Errorf("WriteWAV(%q): RIFF size %d data size %d, file is %d bytes", tt.text, riffLen, dataLen, len(b))
+		}
+
+		if rate := binary.LittleEndian.Uint32(b[24:28]); int(rate) != s.SampleRate {
+			t.Errorf("WriteWAV(%q): sample rate %d, want %d", tt.text, rate, s.SampleRate)
+		}
+
+		secs := float64(dataLen/2) / float64(s.SampleRate)
+		if math.Abs(secs-tt.secs) > 0.01*tt.secs+0.001 {
+			t.Errorf("WriteWAV(%q): %.3f seconds, want %.3f", tt.text, secs, tt.secs)
+		}
+	}
+}
+
+func TestWriteWAVTooLong(t *testing.T) {
+	// 200,000 seconds of silence is over 4 GiB of samples
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, "e |S200000000 e", DefaultSound()); err != ErrWAVTooLong {
+		t.Errorf("WriteWAV = %v, want ErrWAVTooLong", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("WriteWAV wrote %d bytes before finding it too long", buf.Len())
+	}
+}
+
+func TestWordTimes(t *testing.T) {
+	s := DefaultSound()
+
+	tests := []struct {
+		text  string
+		times []float64
+	}{
+		{"e e", []float64{0, 0.48}},           // a dit and a word gap is 8 dits of 0.06
+		{"e |S1000 e", []float64{0, 1.48}},    // the silence is before the second word
+		{"|w10 e |w20 e", []float64{0, 0.96}}, // the first word at 10 wpm
+		{"t <BT> e", []float64{0, 0.6, 1.8}},  // t is 10 dits with the gap, <BT> 20
+	}
+
+	for _, tt := range tests {
+		times := WordTimes(tt.text, s)
+
+		if len(times) != len(tt.times) {
+			t.Errorf("WordTimes(%q) = %v, want %v", tt.text, times, tt.times)
+			continue
+		}
+
+		for i := range times {
+			if math.Abs(times[i]-tt.times[i]) > 0.001 {
+				t.Errorf("WordTimes(%q) = %v, want %v", tt.text, times, tt.times)
+				break
+			}
+		}
+	}
+}
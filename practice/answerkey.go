@@ -0,0 +1,85 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+// AnswerKey returns text, as made by Generate, with only what is heard, the
+// same words WriteWAV keys: no header or ebook2cw commands. Each line of
+// text with something heard is a numbered line of the key, then the meaning
+// of each -vocab word heard.
+func (g *Generator) AnswerKey(text string) string {
+	var sb strings.Builder
+
+	heard := []string{}
+
+	for i, words := range g.heardLines(text) {
+		fmt.Fprintf(&sb, "%3d  %s\n", i+1, strings.Join(words, " "))
+		heard = append(heard, words...)
+	}
+
+	if meanings := g.meanings(heard); len(meanings) > 0 {
+		sb.WriteString("\nMeanings:\n")
+		for _, m := range meanings {
+			fmt.Fprintf(&sb, "     %s\n", m)
+		}
+	}
+
+	return sb.String()
+}
+
+// heardLines returns the words heard on each line of text that has any
+func (g *Generator) heardLines(text string) [][]string {
+	heard := [][]string{}
+
+	for _, tokens := range g.bodyLines(text) {
+		words := []string{}
+		for _, t := range tokens {
+			if t.Kind == morse.Word {
+				words = append(words, t.Text)
+			}
+		}
+
+		if len(words) > 0 {
+			heard = append(heard, words)
+		}
+	}
+
+	return heard
+}
+
+// bodyLines returns the tokens of each line of text without the words of
+// the header, which printStrBuf may have wrapped onto more than one line.
+// The header's ebook2cw commands, like |f600, are kept.
+func (g *Generator) bodyLines(text string) [][]morse.Token {
+	skip := morse.Words(g.header())
+	lines := [][]morse.Token{}
+
+	for _, line := range strings.Split(text, "\n") {
+		tokens := []morse.Token{}
+
+		for _, t := range morse.Parse(line) {
+			if t.Kind == morse.Word && len(skip) > 0 && t.Text == skip[0] {
+				skip = skip[1:]
+				continue
+			}
+
+			// the header is done
+			if t.Kind == morse.Word {
+				skip = nil
+			}
+			tokens = append(tokens, t)
+		}
+
+		lines = append(lines, tokens)
+	}
+
+	return lines
+}
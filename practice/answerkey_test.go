@@ -0,0 +1,59 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+func TestAnswerKey(t *testing.T) {
+	opts := testOptions()
+	opts.Header = "cq de wa2nfn |f700"
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the header wrapped, a speed command, and a line with nothing heard
+	text := "cq de\nwa2nfn |f700 abc |w25 def\n|S500\nghi"
+	want := "  1  abc def\n  2  ghi\n"
+
+	if got := g.AnswerKey(text); got != want {
+		t.Errorf("AnswerKey(%q) = %q, want %q", text, got, want)
+	}
+}
+
+func TestAnswerKeyHeard(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Num = 40
+	opts.Len = 30
+	opts.Header = "vvv de wa2nfn"
+	opts.EBLow = 15
+	opts.EBSlow = 3
+	opts.EBFast = 3
+	opts.EBSF = "<BT>"
+
+	g, text := generator(t, opts)
+	key := g.AnswerKey(text)
+
+	if strings.Contains(key, "|") {
+		t.Errorf("key has an ebook2cw command:\n%s", key)
+	}
+
+	// the key is the words keyed in the WAV, in order
+	keyed := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(key), "\n") {
+		keyed = append(keyed, strings.Fields(line)[1:]...)
+	}
+
+	if got, want := strings.Join(keyed, " "), strings.Join(morse.Words(g.wavText(text)), " "); got != want {
+		t.Errorf("key words %q, want the WAV words %q", got, want)
+	}
+}
@@ -0,0 +1,176 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"strings"
+	"unicode"
+)
+
+// callsign prefixes, "#" is where the call area digit goes
+var (
+	usPrefix1 = []string{"k#", "n#", "w#"}
+	usPrefix2 []string // ka# to wz#, aa# to al#, made in init
+
+	dxPrefixes = []string{
+		"g#", "m#", "f#", "i#", "dl#", "ea#", "on#", "pa#", "oe#", "hb9",
+		"oz#", "sm#", "la#", "oh#", "sp#", "ok#", "om#", "ha#", "yo#", "lz#",
+		"s5#", "9a#", "ur#", "ua#", "ei#", "gm#", "gw#", "ct#", "sv#", "4x#",
+		"ja#", "bv#", "hl#", "vu#", "vk#", "zl#", "zs#", "lu#", "py#", "ce#",
+		"xe#", "ve#", "co#", "kp4", "kh6", "kl7",
+	}
+
+	// portable and such, added to one call in ten
+	callPortable = []string{"/p", "/qrp", "/mm"}
+)
+
+// US formats, letters before and after the call area digit
+var usFormats = []struct{ prefix, suffix int }{
+	{1, 1}, {1, 2}, {1, 3}, {2, 1}, {2, 2}, {2, 3},
+}
+
+func init() {
+	for _, first := range "knw" {
+		for second := 'a'; second <= 'z'; second++ {
+			usPrefix2 = append(usPrefix2, string([]rune{first, second, '#'}))
+		}
+	}
+
+	for second := 'a'; second <= 'l'; second++ {
+		usPrefix2 = append(usPrefix2, string([]rune{'a', second, '#'}))
+	}
+}
+
+// callTable is the part of the prefix table the cglist (or lesson) can send
+type callTable struct {
+	letters  []rune
+	digits   []rune
+	us1      []string
+	us2      []string
+	dx       []string
+	portable []string
+}
+
+// setupCallsigns keeps only the prefixes and suffixes made of chars,
+// the cglist characters for -callsigns
+func (g *Generator) setupCallsigns(errs *ValidationErrors, chars []rune) {
+	o := &g.opts
+	t := &callTable{}
+
+	allowed := make(map[rune]bool)
+	for _, r := range chars {
+		r = unicode.ToLower(r)
+		allowed[r] = true
+
+		if r >= 'a' && r <= 'z' {
+			t.letters = append(t.letters, r)
+		} else if r >= '0' && r <= '9' {
+			t.digits = append(t.digits, r)
+		}
+	}
+
+	// only "#" is left to fill
+	canSend := func(s string) bool {
+		for _, r := range s {
+			if r != '#' && !allowed[r] {
+				return false
+			}
+		}
+		return true
+	}
+
+	keep := func(list []string) []string {
+		kept := []string{}
+		for _, s := range list {
+			if canSend(s) && (len(t.digits) > 0 || !strings.Contains(s, "#")) {
+				kept = append(kept, s)
+			}
+		}
+		return kept
+	}
+
+	t.us1 = keep(usPrefix1)
+	t.us2 = keep(usPrefix2)
+	t.dx = keep(dxPrefixes)
+	t.portable = keep(callPortable)
+
+	if len(t.letters) == 0 || len(t.us1)+len(t.us2)+len(t.dx) == 0 {
+		errs.add("callsigns", o.Callsigns, "cglist (or lesson) <%s> needs a digit and the letters of at least one prefix, like k n w", o.Cglist)
+		return
+	}
+
+	g.calls = t
+}
+
+// makeCallsigns returns num random callsigns, to be output like words
+func (g *Generator) makeCallsigns() []string {
+	calls := make([]string, 0, g.opts.Num)
+	seen := make(map[string]bool)
+
+	// a small lesson may not have enough different calls for unique
+	for tries := 0; len(calls) < g.opts.Num && tries < 20*g.opts.Num; tries++ {
+		call := g.callsign()
+
+		if g.opts.Unique {
+			if seen[call] {
+				continue
+			}
+			seen[call] = true
+		}
+
+		calls = append(calls, call)
+	}
+
+	return calls
+}
+
+// callsign makes one call from the table
+func (g *Generator) callsign() string {
+	return g.makeCall(true)
+}
+
+// makeCall makes a US call, or if anywhere is true maybe DX or portable
+func (g *Generator) makeCall(anywhere bool) string {
+	t := g.calls
+	prefix := ""
+	suffix := 1 + g.rng.Intn(3)
+
+	us := len(t.us1)+len(t.us2) > 0
+	if anywhere && (!us || len(t.dx) > 0 && g.rng.Intn(3) == 0) {
+		prefix = t.dx[g.rng.Intn(len(t.dx))]
+	} else {
+		f := usFormats[g.rng.Intn(len(usFormats))]
+		suffix = f.suffix
+
+		// use the other size if this lesson has none
+		if f.prefix == 1 && len(t.us1) > 0 || len(t.us2) == 0 {
+			prefix = t.us1[g.rng.Intn(len(t.us1))]
+		} else {
+			prefix = t.us2[g.rng.Intn(len(t.us2))]
+		}
+	}
+
+	call := []rune{}
+	for _, r := range prefix {
+		if r == '#' {
+			r = g.pickRune(t.digits)
+		}
+		call = append(call, r)
+	}
+
+	for ; suffix > 0; suffix-- {
+		call = append(call, g.pickRune(t.letters))
+	}
+
+	if anywhere && len(t.portable) > 0 && g.rng.Intn(10) == 0 {
+		call = append(call, []rune(t.portable[g.rng.Intn(len(t.portable))])...)
+	}
+
+	if g.opts.Caps {
+		return strings.ToUpper(string(call))
+	}
+
+	return string(call)
+}
@@ -0,0 +1,63 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCallsigns(t *testing.T) {
+	opts := testOptions()
+	opts.Callsigns = true
+	opts.Num = 20
+
+	checkGolden(t, "callsigns", generate(t, opts))
+}
+
+func TestCallsignsFormat(t *testing.T) {
+	// prefix with its call area digit, 1 to 3 letters, maybe portable
+	call := regexp.MustCompile(`^[a-z0-9]{1,3}[0-9][a-z]{1,3}(/p|/qrp|/mm)?$`)
+
+	tests := []struct {
+		name   string
+		set    func(o *Options)
+		allow  string // the only characters of the calls
+		unique bool
+	}{
+		{"default cglist", func(o *Options) {}, "", false},
+		{"unique", func(o *Options) { o.Unique = true }, "", true},
+		{"cglist", func(o *Options) { o.Cglist = "kwa1-3" }, "kwa123", false},
+		{"caps", func(o *Options) { o.Caps = true }, "", false},
+	}
+
+	for _, tt := range tests {
+		opts := testOptions()
+		opts.Callsigns = true
+		opts.Num = 300
+		tt.set(&opts)
+
+		seen := make(map[string]bool)
+		for _, c := range strings.Fields(generate(t, opts)) {
+			if tt.unique && seen[c] {
+				t.Errorf("%s: %s sent twice", tt.name, c)
+			}
+			seen[c] = true
+
+			if opts.Caps != (c == strings.ToUpper(c)) {
+				t.Errorf("%s: %s, caps is %v", tt.name, c, opts.Caps)
+			}
+
+			c = strings.ToLower(c)
+			if !call.MatchString(c) {
+				t.Errorf("%s: %s is not a callsign", tt.name, c)
+			}
+			if tt.allow != "" && strings.Trim(c, tt.allow) != "" {
+				t.Errorf("%s: %s has a character not in %s", tt.name, c, tt.allow)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"io"
+)
+
+// buildCharSlice - create a byte slice to use for codeGroups
+// num is the number of code groups, or words in mixedMode
+func (g *Generator) buildCharSlice(num int) []rune {
+
+	// make slice of chars for MAY NEED use later
+	// if word mode, we only need for MAX possible codeGroups
+	numChars := 0
+
+	if g.opts.MixedMode == 0 {
+		numChars = g.opts.CGMax * num // may be extra
+	} else {
+		numChars = g.opts.CGMax * (num / g.opts.MixedMode) // may be extra
+	}
+
+	if g.weights != nil || g.profile != nil {
+		return g.weightedCharSlice(numChars)
+	}
+
+	cgSlice := g.cglistRune
+
+	// charSlice now has the user given list of chars
+	// copy so the draws below don't eat the cglist itself
+	charSlice := make([]rune, 0, numChars)
+	charSlice = append(charSlice, cgSlice...)
+
+	// then just copy cgSlice into charSlice as needed
+	if len(cgSlice) < numChars {
+		// flush out the charSlice to max we may need
+		factor := numChars / len(cgSlice)
+		factor-- // we have the original already
+
+		// only does FULL slice
+		for ; factor > 0; factor-- {
+			charSlice = append(charSlice, cgSlice...)
+		}
+
+		// may still be a partial shortage
+		howShort := numChars - len(charSlice)
+
+		for _, key := range cgSlice {
+			charSlice = append(charSlice, key)
+
+			if howShort == 0 {
+				break
+			}
+			howShort--
+		}
+	}
+
+	return charSlice
+}
+
+// make random code groups
+// uses the presaved chars in charSlice based on uniform distribution
+func (g *Generator) makeGroups(w io.Writer) error {
+	strBuf := g.header()
+	var tmpOut []rune
+
+	charSlice := g.buildCharSlice(g.opts.Num)
+
+	// make the code groups
+	for i := 0; i < g.opts.Num; i++ {
+
+		// tmpOut is our code group
+		tmpOut, charSlice = g.makeSingleGroup(charSlice)
+
+		// text repeat!
+		if g.opts.Repeat > 0 {
+			// we need to repeat
+			temp := tmpOut
+
+			for cnt := 1; cnt < g.opts.Repeat; cnt++ {
+				// wordOut is the word plus trailing space already
+				temp = append(temp, tmpOut...)
+			}
+			strBuf += string(temp)
+
+		} else {
+			// non repeat case
+			strBuf += string(tmpOut)
+		}
+
+		strBuf += g.delimiter()
+	}
+
+	return g.printStrBuf(strBuf, w)
+}
+
+/*
+** make a code group of random length
+** character pulled from byte slice that even distribution
+** of characters.
+ */
+func (g *Generator) makeSingleGroup(charSlice []rune) ([]rune, []rune) {
+	var cg []rune
+	var tmp rune
+	gl := g.opts.CGMin
+
+	if g.pairs != nil {
+		return g.confusableGroup(), charSlice
+	}
+
+	// choose random grp len from min to max
+	if g.opts.CGMax != g.opts.CGMin {
+		gl = g.rng.Intn(g.opts.CGMax-g.opts.CGMin) + g.opts.CGMin
+	}
+
+	for i := 0; i < gl; i++ {
+		if len(charSlice) < gl {
+			break
+		}
+		tmp, charSlice = g.getRandomChar(charSlice)
+		cg = append(cg, tmp)
+	}
+
+	cg = g.emphasize(cg)
+	cg = append(cg, ' ')
+	return cg, charSlice
+}
+
+// used for codeGroup
+func (g *Generator) getRandomChar(randCharSlice []rune) (rune, []rune) {
+	sLen := len(randCharSlice)
+
+	index := g.rng.Intn(sLen)
+	newChar := randCharSlice[index] // to be returned
+
+	// eat the value used
+	sLen--
+	randCharSlice[index] = randCharSlice[sLen]
+	randCharSlice = randCharSlice[:sLen]
+
+	return newChar, randCharSlice
+}
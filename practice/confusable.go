@@ -0,0 +1,156 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/wa2nfn/cwpt/koch"
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+// setupConfusable finds the -confusable most similar sounding pairs, of the
+// cglist characters for code groups, or of the inlist characters for words
+func (g *Generator) setupConfusable(errs *ValidationErrors) {
+	o := &g.opts
+
+	if o.Confusable == 0 {
+		return
+	}
+
+	if o.Confusable < 0 || o.Confusable > MaxConfusable {
+		errs.add("confusable", o.Confusable, "number of the most confusable pairs to drill, minimum 0(off), maximum %d", MaxConfusable)
+		return
+	}
+
+	if o.NR || o.generated() || o.Pseudo || o.NGram > 0 || o.Sentences {
+		errs.add("confusable", o.Confusable, "only for code groups, mixedMode or words from the in file, not NR or the other modes")
+		return
+	}
+
+	chars := g.cglistRune
+	if !o.CodeGroups && o.MixedMode == 0 {
+		list, err := koch.ExpandRanges(o.Inlist)
+		if err != nil {
+			errs.add("inlist", o.Inlist, "%v", err)
+			return
+		}
+		chars = []rune(list)
+	}
+
+	// one of each, either case
+	unique := []rune{}
+	seen := make(map[rune]bool)
+	for _, r := range chars {
+		r = unicode.ToLower(r)
+		if !seen[r] {
+			seen[r] = true
+			unique = append(unique, r)
+		}
+	}
+
+	pairs := morse.Pairs(unique)
+	if len(pairs) == 0 {
+		errs.add("confusable", o.Confusable, "the characters <%s> don't have 2 with Morse codes to compare", string(unique))
+		return
+	}
+
+	g.pairs = topPairs(pairs, o.Confusable)
+}
+
+// topPairs returns the n most alike of pairs, most alike first. Of pairs as
+// alike, the one with the fewest characters already taken is next, so the
+// pairs drilled cover as many characters as they can.
+func topPairs(pairs []morse.Pair, n int) []morse.Pair {
+	left := append([]morse.Pair{}, pairs...)
+	top := []morse.Pair{}
+	taken := make(map[rune]bool)
+
+	// characters of p not yet taken
+	fresh := func(p morse.Pair) int {
+		n := 0
+		if !taken[p.A] {
+			n++
+		}
+		if !taken[p.B] {
+			n++
+		}
+		return n
+	}
+
+	for len(top) < n && len(left) > 0 {
+		best := 0
+		for i := 1; i < len(left) && left[i].Score == left[0].Score; i++ {
+			if fresh(left[i]) > fresh(left[best]) {
+				best = i
+			}
+		}
+
+		p := left[best]
+		top = append(top, p)
+		taken[p.A] = true
+		taken[p.B] = true
+		left = append(left[:best], left[best+1:]...)
+	}
+
+	return top
+}
+
+// confusableGroup is a code group mostly of the two characters of one pair,
+// the more alike the pair the more often it is chosen
+func (g *Generator) confusableGroup() []rune {
+	pair := g.pairs[g.weightedIndex(len(g.pairs), func(i int) float64 { return g.pairs[i].Score })]
+
+	gl := g.opts.CGMin
+	if g.opts.CGMax != g.opts.CGMin {
+		gl = g.rng.Intn(g.opts.CGMax-g.opts.CGMin) + g.opts.CGMin
+	}
+
+	// one in four is any cglist character, so the pair can't be guessed,
+	// the rest are as often one of the pair as the other
+	cg := []rune{}
+	for i := 0; i < gl; i++ {
+		switch n := g.rng.Intn(8); {
+		case n < 2:
+			cg = append(cg, g.pickRune(g.cglistRune))
+		case n < 5:
+			cg = append(cg, pair.A)
+		default:
+			cg = append(cg, pair.B)
+		}
+	}
+
+	s := string(cg)
+	if g.opts.Caps {
+		s = strings.ToUpper(s)
+	} else {
+		s = strings.ToLower(s)
+	}
+
+	return append([]rune(s), ' ')
+}
+
+// confusableOrder shuffles words so those with the characters of the pairs
+// come first, a word with both of a pair the most likely
+func (g *Generator) confusableOrder(words []string) {
+	g.weightedShuffle(words, func(w string) float64 {
+		lw := strings.ToLower(w)
+		weight := 0.1 // a word without any still has a small chance
+
+		for _, p := range g.pairs {
+			a := strings.ContainsRune(lw, p.A)
+			b := strings.ContainsRune(lw, p.B)
+
+			if a && b {
+				weight += 3 * p.Score
+			} else if a || b {
+				weight += p.Score
+			}
+		}
+
+		return weight
+	})
+}
@@ -0,0 +1,86 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+func TestConfusable(t *testing.T) {
+	opts := testOptions()
+	opts.CodeGroups = true
+	opts.Confusable = 3
+	opts.Num = 20
+
+	checkGolden(t, "confusable", generate(t, opts))
+}
+
+func TestTopPairs(t *testing.T) {
+	pairs := []morse.Pair{
+		{A: 'b', B: '6', Score: 1},
+		{A: 'b', B: '9', Score: 1},
+		{A: '6', B: '9', Score: 1},
+		{A: 'c', B: '/', Score: 1},
+		{A: 'x', B: 'y', Score: 0.5},
+		{A: 'b', B: 'y', Score: 0.5},
+	}
+
+	// of the pairs as alike, those with new characters first
+	want := []morse.Pair{pairs[0], pairs[3], pairs[1], pairs[2], pairs[4]}
+	if got := topPairs(pairs, 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("topPairs = %v, want %v", got, want)
+	}
+
+	if got := topPairs(pairs[:2], 5); !reflect.DeepEqual(got, pairs[:2]) {
+		t.Errorf("topPairs of fewer than n = %v, want them all", got)
+	}
+}
+
+func TestConfusableDefault(t *testing.T) {
+	// the top pairs of the default cglist each drill different characters
+	opts := testOptions()
+	opts.CodeGroups = true
+	opts.Confusable = 3
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chars := make(map[rune]bool)
+	for i, p := range g.pairs {
+		chars[p.A] = true
+		chars[p.B] = true
+
+		if i > 0 && p.Score > g.pairs[i-1].Score {
+			t.Errorf("pair %v is more alike than the one before it", p)
+		}
+	}
+
+	if len(g.pairs) != 3 || len(chars) != 6 {
+		t.Errorf("pairs %v, want 3 with 6 characters", g.pairs)
+	}
+}
+
+func TestConfusableGroup(t *testing.T) {
+	opts := testOptions()
+	opts.CodeGroups = true
+	opts.Confusable = 1
+	opts.Num = 2000
+
+	g, text := generator(t, opts)
+	p := g.pairs[0]
+	a := strings.Count(text, string(p.A))
+	b := strings.Count(text, string(p.B))
+
+	// each of the pair as often as the other, about 3/8 of the characters
+	if a < b*9/10 || b < a*9/10 {
+		t.Errorf("%c %d times, %c %d times, want about the same", p.A, a, p.B, b)
+	}
+}
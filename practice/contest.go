@@ -0,0 +1,94 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// contests, name to the maker of one exchange
+var contests = map[string]func(g *Generator) string{
+	"CQWW": func(g *Generator) string { return g.rst() + " " + g.cut(fmt.Sprintf("%d", 1+g.rng.Intn(40))) },
+	"WPX":  func(g *Generator) string { return g.rst() + " " + g.cut(fmt.Sprintf("%03d", g.nextSerial())) },
+	"SS": func(g *Generator) string {
+		return fmt.Sprintf("%s %s %s %s %s", g.cut(fmt.Sprintf("%d", g.nextSerial())), g.pick(ssPrecedence),
+			g.makeCall(false), g.cut(fmt.Sprintf("%02d", g.rng.Intn(100))), g.pick(arrlSections))
+	},
+	"FD": func(g *Generator) string {
+		return fmt.Sprintf("%d%s %s", 1+g.rng.Intn(g.rng.Intn(20)+1), g.pick(fdClasses), g.pick(arrlSections))
+	},
+	"NAQP": func(g *Generator) string { return g.pick(qsoNames) + " " + g.pick(naqpLocations) },
+}
+
+var (
+	ssPrecedence = []string{"q", "a", "b", "u", "m", "s"}
+	fdClasses    = []string{"a", "a", "a", "b", "c", "d", "e", "f"}
+	arrlSections = []string{
+		"ct", "ema", "me", "nh", "ri", "vt", "wma", "eny", "nli", "nnj", "nny", "snj", "wny",
+		"de", "epa", "mdc", "wpa", "al", "ga", "ky", "nc", "nfl", "sc", "sfl", "wcf", "tn",
+		"va", "pr", "vi", "ar", "la", "ms", "nm", "ntx", "ok", "stx", "wtx", "eb", "lax",
+		"org", "sb", "scv", "sdg", "sf", "sjv", "sv", "pac", "az", "ewa", "id", "mt", "nv",
+		"or", "ut", "wwa", "wy", "ak", "mi", "oh", "wv", "il", "in", "wi", "co", "ia", "ks",
+		"mn", "mo", "ne", "nd", "sd", "mar", "nl", "qc", "one", "onn", "ons", "gta", "mb",
+		"sk", "ab", "bc", "nt",
+	}
+	naqpLocations = []string{
+		"ma", "ny", "pa", "oh", "mi", "il", "tx", "ca", "wa", "or", "co", "az", "fl", "ga",
+		"nc", "va", "md", "nj", "ct", "me", "mn", "wi", "mo", "tn", "on", "qc", "bc", "dx",
+	}
+)
+
+// ContestNames returns the -contest choices.
+func ContestNames() []string {
+	names := make([]string, 0, len(contests))
+	for name := range contests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// makeExchanges returns num exchanges of the contest, each one is a word
+// so repeat and the delimiter act on the whole exchange
+func (g *Generator) makeExchanges() []string {
+	exchange := contests[g.opts.Contest]
+	words := make([]string, 0, g.opts.Num)
+
+	// a serial starts anywhere, the running station works others in between
+	g.serial = g.rng.Intn(300)
+
+	for i := 0; i < g.opts.Num; i++ {
+		ex := exchange(g)
+		if g.opts.Caps {
+			ex = strings.ToUpper(ex)
+		}
+		words = append(words, ex)
+	}
+
+	return words
+}
+
+// the next serial number sent
+func (g *Generator) nextSerial() int {
+	g.serial += 1 + g.rng.Intn(3)
+	return g.serial
+}
+
+// signal report, always 599 in a contest
+func (g *Generator) rst() string {
+	return g.cut("599")
+}
+
+// cut numbers, 0 sent as t and 9 as n
+func (g *Generator) cut(num string) string {
+	if !g.opts.Cut {
+		return num
+	}
+
+	return strings.NewReplacer("0", "t", "9", "n").Replace(num)
+}
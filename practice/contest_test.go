@@ -0,0 +1,75 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestContest(t *testing.T) {
+	for _, name := range ContestNames() {
+		opts := testOptions()
+		opts.Contest = name
+		opts.Num = 8
+
+		checkGolden(t, "contest_"+strings.ToLower(name), generate(t, opts))
+	}
+}
+
+func TestContestExchanges(t *testing.T) {
+	// one exchange, as many words as it has
+	exchange := map[string]*regexp.Regexp{
+		"CQWW": regexp.MustCompile(`^599 [0-9]+$`),
+		"WPX":  regexp.MustCompile(`^599 [0-9]{3,}$`),
+		"SS":   regexp.MustCompile(`^[0-9]+ [qabums] [a-z0-9]+ [0-9]{2} [a-z]+$`),
+		"FD":   regexp.MustCompile(`^[0-9]+[a-f] [a-z]+$`),
+		"NAQP": regexp.MustCompile(`^[a-z]+ [a-z]+$`),
+	}
+
+	for name, re := range exchange {
+		opts := testOptions()
+		opts.Contest = strings.ToLower(name)
+		opts.Num = 50
+		opts.Len = 500
+
+		words := strings.Fields(generate(t, opts))
+		n := len(strings.Fields(re.String()))
+		if len(words) != opts.Num*n {
+			t.Fatalf("%s: %d words, want %d exchanges of %d", name, len(words), opts.Num, n)
+		}
+
+		last := 0
+		for i := 0; i < len(words); i += n {
+			ex := strings.Join(words[i:i+n], " ")
+			if !re.MatchString(ex) {
+				t.Errorf("%s: exchange %q", name, ex)
+			}
+
+			// the serial number goes up
+			if name == "WPX" {
+				serial, _ := strconv.Atoi(words[i+1])
+				if serial <= last {
+					t.Errorf("%s: serial %d after %d", name, serial, last)
+				}
+				last = serial
+			}
+		}
+	}
+}
+
+func TestContestCut(t *testing.T) {
+	opts := testOptions()
+	opts.Contest = "CQWW"
+	opts.Num = 100
+	opts.Cut = true
+
+	text := generate(t, opts)
+	if strings.ContainsAny(text, "09") || !strings.Contains(text, "5nn") {
+		t.Errorf("cut numbers have 0 or 9, or no 5nn:\n%s", text)
+	}
+}
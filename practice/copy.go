@@ -0,0 +1,69 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"strings"
+
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+// CopyItem is one word (or code group, callsign, ...) the student copies,
+// and when it starts in the WriteWAV audio, in seconds.
+type CopyItem struct {
+	Text  string
+	Start float64
+}
+
+// CopyItems returns what the student copies from text, as made by the last
+// Generate: the words heard, less the EB_SF and EB_FS alerts, which Generate
+// notes as it puts them in.
+func (g *Generator) CopyItems(text string) []CopyItem {
+	wavText := g.wavText(text)
+	heard := morse.Words(wavText)
+	times := morse.WordTimes(wavText, g.sound())
+
+	// the heard words of each alert, counting the words up to it
+	alert := make([]bool, len(heard))
+	n, from := 0, 0
+	for i := 0; i+1 < len(g.alerts); i += 2 {
+		start, end := g.alerts[i], g.alerts[i+1]
+		if start > len(text) || end > len(text) {
+			break
+		}
+
+		if i == 0 {
+			// the header isn't heard
+			n = len(morse.Words(g.wavText(text[:start])))
+		} else {
+			n += len(morse.Words(text[from:start]))
+		}
+
+		for k := 0; k < len(morse.Words(text[start:end])) && n < len(heard); k++ {
+			alert[n] = true
+			n++
+		}
+		from = end
+	}
+
+	items := []CopyItem{}
+	for i, w := range heard {
+		if !alert[i] {
+			items = append(items, CopyItem{Text: w, Start: times[i]})
+		}
+	}
+
+	return items
+}
+
+// CopyText is the text of items, what a -copy file is scored against.
+func CopyText(items []CopyItem) string {
+	words := make([]string, 0, len(items))
+	for _, item := range items {
+		words = append(words, item.Text)
+	}
+
+	return strings.Join(words, " ")
+}
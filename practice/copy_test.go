@@ -0,0 +1,102 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+func TestCopyItems(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(o *Options)
+	}{
+		{"mixed mode", func(o *Options) { o.MixedMode = 3 }},
+		{"slow fast", func(o *Options) { o.EBLow = 15; o.EBStep = 5; o.EBSlow = 3; o.EBFast = 4 }},
+		{"ramp", func(o *Options) { o.EBLow = 15; o.EBStep = 5; o.EBNum = 3; o.EBRamp = true }},
+	}
+
+	for _, tt := range tests {
+		// alerts no word of the text has show where the alerts are
+		opts := testOptions()
+		opts.Input = testText
+		opts.Num = 30
+		opts.EBSF = "vvv"
+		opts.EBFS = "vvv"
+		tt.set(&opts)
+
+		g, text := generator(t, opts)
+		alerts := map[int]bool{}
+		for i, w := range morse.Words(g.wavText(text)) {
+			if w == "vvv" {
+				alerts[i] = true
+			}
+		}
+		if len(alerts) == 0 {
+			t.Fatalf("%s: no alerts in %q", tt.name, text)
+		}
+
+		// the same session, with alerts that are also words of the text
+		opts.EBSF = "the"
+		opts.EBFS = "<BT>"
+		g, text = generator(t, opts)
+
+		wavText := g.wavText(text)
+		times := morse.WordTimes(wavText, g.sound())
+		want := []CopyItem{}
+		for i, w := range morse.Words(wavText) {
+			if !alerts[i] {
+				want = append(want, CopyItem{Text: w, Start: times[i]})
+			}
+		}
+
+		if got := g.CopyItems(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: CopyItems = %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestCopyItemsHeader(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Num = 5
+	opts.Header = "vvv de wa2nfn"
+	opts.MixedMode = 2
+	opts.EBSF = "the"
+
+	g, text := generator(t, opts)
+	items := g.CopyItems(text)
+
+	heard := morse.Words(g.wavText(text))
+	if len(items) == 0 || len(items) >= len(heard) {
+		t.Fatalf("%d items of %d words heard, want the alerts left out", len(items), len(heard))
+	}
+
+	for _, item := range items {
+		if item.Text == "vvv" || item.Text == "wa2nfn" {
+			t.Errorf("item %q of the header", item.Text)
+		}
+	}
+}
+
+// generator returns the generator of opts and its text
+func generator(t *testing.T, opts Options) (*Generator, string) {
+	t.Helper()
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	text, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	return g, text
+}
@@ -0,0 +1,63 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"compress/gzip"
+	"embed"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// the built-in word lists, gzipped, one lower case word per line.
+// english is the words of US television and film scripts, most frequent
+// first (the zxcvbn us_tv_and_film list, MIT license, see dict/NOTICE),
+// less those a spelling dictionary doesn't have in lower case, like names.
+// ham is common ham radio words.
+//
+//go:embed dict/english.txt.gz dict/ham.txt.gz
+var dictFiles embed.FS
+
+// Dicts are the -dict choices, the built-in word lists used when there is no in file.
+var Dicts = []string{"english", "ham", "all"}
+
+// setupDict checks the -dict choice
+func (g *Generator) setupDict(errs *ValidationErrors) {
+	o := &g.opts
+	o.Dict = strings.ToLower(o.Dict)
+
+	for _, d := range Dicts {
+		if o.Dict == d {
+			return
+		}
+	}
+
+	errs.add("dict", o.Dict, "choices are (case insensitive): %s", strings.Join(Dicts, ", "))
+}
+
+// dictReader returns the words of the -dict lists, english first when all
+func (g *Generator) dictReader() (io.Reader, error) {
+	names := []string{g.opts.Dict}
+	if g.opts.Dict == "all" {
+		names = []string{"english", "ham"}
+	}
+
+	readers := []io.Reader{}
+	for _, name := range names {
+		file, err := dictFiles.Open("dict/" + name + ".txt.gz")
+		if err != nil {
+			return nil, err
+		}
+
+		zr, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("built-in word list <%s>: %v", name, err)
+		}
+		readers = append(readers, zr)
+	}
+
+	return io.MultiReader(readers...), nil
+}
@@ -0,0 +1,62 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+// dictWords returns the words of a built-in word list
+func dictWords(t *testing.T, dict string) map[string]bool {
+	t.Helper()
+
+	g := &Generator{opts: Options{Dict: dict}}
+	r, err := g.dictReader()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	words := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		words[scanner.Text()] = true
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return words
+}
+
+func TestDictEnglish(t *testing.T) {
+	words := dictWords(t, "english")
+
+	for _, w := range strings.Fields("skaara newmeat tita isnt mary london monday") {
+		if words[w] {
+			t.Errorf("%s is in the english list", w)
+		}
+	}
+	for _, w := range strings.Fields("the and house radio") {
+		if !words[w] {
+			t.Errorf("%s is not in the english list", w)
+		}
+	}
+}
+
+func TestDictSample(t *testing.T) {
+	words := dictWords(t, "english")
+
+	opts := testOptions()
+	opts.Dict = "english"
+	opts.Num = 500
+
+	for _, w := range strings.Fields(generate(t, opts)) {
+		if !words[w] {
+			t.Errorf("%s was sent but is not in the english list", w)
+		}
+	}
+}
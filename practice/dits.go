@@ -0,0 +1,68 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+// setupDits checks the options for word length in dit units
+func (g *Generator) setupDits(errs *ValidationErrors) {
+	o := &g.opts
+
+	if o.MinDits < 0 {
+		errs.add("minDits", o.MinDits, "must be >= 0, 0(off)")
+	}
+
+	if o.MaxDits < 0 {
+		errs.add("maxDits", o.MaxDits, "must be >= 0, 0(off)")
+	}
+
+	if o.MaxDits > 0 && o.MinDits > o.MaxDits {
+		errs.add("minDits", o.MinDits, "must be <= maxDits <%d>", o.MaxDits)
+	}
+
+	o.DitOrder = strings.ToLower(o.DitOrder)
+	if o.DitOrder != "" && o.DitOrder != "asc" && o.DitOrder != "desc" {
+		errs.add("ditOrder", o.DitOrder, "choices are asc or desc")
+	}
+
+	if (o.MinDits > 0 || o.MaxDits > 0 || o.DitOrder != "") && (o.CodeGroups || o.generated() || o.NGram > 0 || o.Sentences) {
+		errs.add("minDits", o.MinDits, "minDits, maxDits and ditOrder are for words from the in file (or vocab, pseudo)")
+	}
+}
+
+// ditsOK is true if word takes minDits to maxDits to send
+func (g *Generator) ditsOK(word string) bool {
+	if g.opts.MinDits == 0 && g.opts.MaxDits == 0 {
+		return true
+	}
+
+	dits, ok := morse.Dits(word)
+	if !ok {
+		return false
+	}
+
+	return dits >= g.opts.MinDits && (g.opts.MaxDits == 0 || dits <= g.opts.MaxDits)
+}
+
+// sortByDits orders words by the time to send them, keeping the order of
+// words that take the same time
+func (g *Generator) sortByDits(words []string) {
+	dits := make(map[string]int, len(words))
+	for _, w := range words {
+		dits[w], _ = morse.Dits(w)
+	}
+
+	sort.SliceStable(words, func(i, j int) bool {
+		if g.opts.DitOrder == "desc" {
+			return dits[words[i]] > dits[words[j]]
+		}
+		return dits[words[i]] < dits[words[j]]
+	})
+}
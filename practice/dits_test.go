@@ -0,0 +1,44 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+func TestDitsRange(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Num = 100
+	opts.MinDits = 20
+	opts.MaxDits = 40
+
+	for _, w := range strings.Fields(generate(t, opts)) {
+		if d, _ := morse.Dits(w); d < opts.MinDits || d > opts.MaxDits {
+			t.Errorf("%s is %d dits, want %d to %d", w, d, opts.MinDits, opts.MaxDits)
+		}
+	}
+}
+
+func TestDitOrder(t *testing.T) {
+	for _, order := range []string{"asc", "DESC"} {
+		opts := testOptions()
+		opts.Input = testText
+		opts.Num = 40
+		opts.DitOrder = order
+
+		last := -1
+		for _, w := range strings.Fields(generate(t, opts)) {
+			d, _ := morse.Dits(w)
+			if last >= 0 && (order == "asc" && d < last || order == "DESC" && d > last) {
+				t.Errorf("%s: %s is %d dits after %d", order, w, d, last)
+			}
+			last = d
+		}
+	}
+}
@@ -0,0 +1,180 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// setupEmphasis finds the newest characters of the lesson, must follow setupLesson
+func (g *Generator) setupEmphasis(errs *ValidationErrors) {
+	o := &g.opts
+
+	if o.Emphasis == 0 {
+		return
+	}
+
+	if o.Emphasis < 0 || o.Emphasis > 100 {
+		errs.add("emphasis", o.Emphasis, "must be >= 0(off) and <= 100 percent")
+		return
+	}
+
+	if o.Lesson == 0 {
+		errs.add("emphasis", o.Emphasis, "requires the lesson option")
+		return
+	}
+
+	if o.NR || o.generated() || o.Pseudo || o.Sentences || o.NGram > 0 || o.Confusable > 0 || o.Vocab != "" || o.Rank == "sample" {
+		errs.add("emphasis", o.Emphasis, "works on code groups and on words chosen from the in file, not with NR, vocab, confusable, rank=sample or the other modes")
+		return
+	}
+
+	// setupLesson left cglist as the lesson characters in tutor order
+	g.lessonChars = []rune(strings.ToLower(o.Cglist))
+
+	if o.EmphasisChars < 1 || o.EmphasisChars > len(g.lessonChars) {
+		errs.add("emphasisChars", o.EmphasisChars, "must be >= 1 and <= the characters in the lesson <%d>", len(g.lessonChars))
+		return
+	}
+
+	g.newChars = g.lessonChars[len(g.lessonChars)-o.EmphasisChars:]
+}
+
+// hasNew reports if word has one of the newest lesson characters
+func (g *Generator) hasNew(word string) bool {
+	return strings.ContainsAny(strings.ToLower(word), string(g.newChars))
+}
+
+// emphasize puts a new character in place of a random one of a code group,
+// when too few of the groups so far have one
+func (g *Generator) emphasize(cg []rune) []rune {
+	if g.newChars == nil || len(cg) == 0 {
+		return cg
+	}
+
+	g.groups++
+	if g.hasNew(string(cg)) {
+		g.groupHits++
+		return cg
+	}
+
+	if g.groupHits*100 < g.opts.Emphasis*g.groups {
+		char := g.newChars[g.rng.Intn(len(g.newChars))]
+		if g.opts.Caps {
+			char = unicode.ToUpper(char)
+		}
+
+		cg[g.rng.Intn(len(cg))] = char
+		g.groupHits++
+	}
+
+	return cg
+}
+
+// emphasizeWords chooses num words from g.wordOrder, the emphasis percent
+// of them with a new character
+func (g *Generator) emphasizeWords() ([]string, error) {
+	with := []string{}
+	without := []string{}
+
+	for _, w := range g.wordOrder {
+		if g.hasNew(w) {
+			with = append(with, w)
+		} else {
+			without = append(without, w)
+		}
+	}
+
+	if len(with) == 0 {
+		return nil, fmt.Errorf("%w\n\nNo word of your input file has the emphasized character(s) <%s>.", ErrNothingToOutput, string(g.newChars))
+	}
+
+	need := (g.opts.Emphasis*g.opts.Num + 99) / 100
+	g.shuffle(with)
+	g.shuffle(without)
+
+	var words []string
+	if g.opts.Unique {
+		if need > len(with) {
+			need = len(with)
+		}
+		words = append(words, with[:need]...)
+
+		// then words without, or the other words with, if too few
+		rest := append(without, with[need:]...)
+		if n := g.opts.Num - need; n < len(rest) {
+			rest = rest[:n]
+		}
+		words = append(words, rest...)
+	} else {
+		if len(without) == 0 {
+			without = with
+		}
+		words = append(g.drawWords(with, need), g.drawWords(without, g.opts.Num-need)...)
+	}
+
+	g.shuffle(words)
+
+	g.wordMap = nil
+	g.wordOrder = nil
+	return words, nil
+}
+
+// n words from pool, each used again only after all the others
+func (g *Generator) drawWords(pool []string, n int) []string {
+	words := make([]string, 0, n)
+
+	for len(words) < n {
+		g.shuffle(pool)
+		if n-len(words) < len(pool) {
+			words = append(words, pool[:n-len(words)]...)
+		} else {
+			words = append(words, pool...)
+		}
+	}
+
+	return words
+}
+
+// EmphasisReport returns how many times each lesson character is heard in
+// text, as made by Generate, the emphasized characters marked with "*".
+// It is empty unless Options.Emphasis is set.
+func (g *Generator) EmphasisReport(text string) string {
+	if g.newChars == nil {
+		return ""
+	}
+
+	count := make(map[rune]int)
+	for _, words := range g.heardLines(text) {
+		for _, w := range words {
+			// a prosign is not its letters
+			if strings.HasPrefix(w, "<") {
+				continue
+			}
+			for _, r := range strings.ToLower(w) {
+				count[r]++
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\nLesson characters sent (* emphasized):\n")
+
+	for i, r := range g.lessonChars {
+		mark := " "
+		if strings.ContainsRune(string(g.newChars), r) {
+			mark = "*"
+		}
+
+		fmt.Fprintf(&sb, "  %c%s%5d", r, mark, count[r])
+		if (i+1)%8 == 0 || i == len(g.lessonChars)-1 {
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
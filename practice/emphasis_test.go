@@ -0,0 +1,90 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEmphasis(t *testing.T) {
+	opts := testOptions()
+	opts.CodeGroups = true
+	opts.Lesson = 10
+	opts.Emphasis = 50
+	opts.Num = 20
+
+	checkGolden(t, "emphasis", generate(t, opts))
+}
+
+func TestEmphasisShare(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(o *Options)
+	}{
+		{"code groups", func(o *Options) { o.CodeGroups = true }},
+		{"words", func(o *Options) { o.Input = testText; o.Lesson = 37 }},
+		{"unique words", func(o *Options) { o.Input = testText; o.Lesson = 37; o.Unique = true; o.Num = 20 }},
+	}
+
+	for _, tt := range tests {
+		opts := testOptions()
+		opts.Lesson = 10
+		opts.Emphasis = 60
+		opts.EmphasisChars = 2
+		opts.Num = 200
+		tt.set(&opts)
+
+		g, text := generator(t, opts)
+		words := strings.Fields(text)
+
+		with := 0
+		for _, w := range words {
+			if g.hasNew(w) {
+				with++
+			}
+		}
+
+		if with*100 < opts.Emphasis*len(words) {
+			t.Errorf("%s: %d of %d with one of %q, want at least %d%%", tt.name, with, len(words), string(g.newChars), opts.Emphasis)
+		}
+	}
+}
+
+func TestEmphasisReport(t *testing.T) {
+	opts := testOptions()
+	opts.CodeGroups = true
+	opts.Lesson = 2
+	opts.Emphasis = 50
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// lesson 2 is k m u, u the newest, a prosign's letters don't count
+	want := "\nLesson characters sent (* emphasized):\n  k     2  m     1  u*    0\n"
+	if got := g.EmphasisReport("kmk <KM> |w20 x"); got != want {
+		t.Errorf("EmphasisReport = %q, want %q", got, want)
+	}
+}
+
+func TestEmphasisNoWords(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Lesson = 30
+	opts.Emphasis = 50
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// no word has 8
+	if _, err := g.Generate(); !errors.Is(err, ErrNothingToOutput) {
+		t.Errorf("Generate error %v, want ErrNothingToOutput", err)
+	}
+}
@@ -0,0 +1,556 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+// Package practice generates c.w. practice text: random words read from a
+// text file, random code groups, or a mix of both, optionally marked up with
+// ebook2cw (or LCWO) speed commands. It is the engine behind the cwpt2 command.
+package practice
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wa2nfn/cwpt/koch"
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+// ErrNothingToOutput is returned by Generate when no word in the input
+// satisfied the options.
+var ErrNothingToOutput = errors.New("Sorry there is nothing to output.\nMake sure the options (or defaults) are not to restrictive (min, max, inlist).\nVerify your input file is sufficiently populated with matchable text.")
+
+// Generator makes practice text for one validated set of Options.
+type Generator struct {
+	opts           Options
+	rng            *rand.Rand
+	seed           int64
+	delimiterSlice []string
+	effDelta       int
+	prelistRune    []rune
+	suflistRune    []rune
+	cglistRune     []rune
+	weights        map[rune]float64  // -weak, nil draws every character evenly
+	profile        map[rune]float64  // -profile of code group characters, nil is uniform
+	pairs          []morse.Pair      // -confusable, the most alike sounding pairs
+	calls          *callTable        // -callsigns, prefixes the cglist can send
+	serial         int               // -contest, the last serial number sent
+	vocab          map[string]string // -vocab, word to its meaning
+	numberKinds    []string          // -numbers, the kinds to make
+	sources        []Source          // the files of the in option
+	stdinText      []byte            // a source of "-", kept to be read again
+	lessonChars    []rune            // -emphasis, the lesson characters in tutor order
+	newChars       []rune            // -emphasis, the newest of lessonChars
+
+	// per run, reset by Generate
+	wordMap    map[string]struct{}
+	wordOrder  []string           // wordMap keys in the order first read, map order isn't repeatable
+	wordFreq   map[string]float64 // times each word was read
+	wordSource map[string]int     // index in sources of the first one with each word
+	groups     int                // code groups made, for -emphasis
+	groupHits  int                // code groups with a new character
+	wordArray  []string
+	proSign    []string
+	alerts     []int // where each EB_SF and EB_FS alert starts and ends in the text
+}
+
+// New validates opts and returns a Generator ready to produce text.
+// Options that depend on others (lesson, tutor, list ranges) are expanded here.
+func New(opts Options) (*Generator, error) {
+	g := &Generator{
+		opts: opts,
+		seed: opts.Seed,
+	}
+
+	// a seed given by the user makes the session repeatable
+	if g.seed == 0 {
+		g.seed = time.Now().UTC().UnixNano()
+	}
+	g.rng = rand.New(rand.NewSource(g.seed))
+
+	if err := g.setup(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Options returns the options in effect, after lesson and list expansion.
+func (g *Generator) Options() Options {
+	return g.opts
+}
+
+// Seed returns the seed in effect, give it back in Options.Seed to get the
+// same practice text again.
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+// Generate returns the practice text.
+func (g *Generator) Generate() (string, error) {
+	var sb strings.Builder
+
+	if err := g.GenerateTo(&sb); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// GenerateTo writes the practice text to w.
+func (g *Generator) GenerateTo(w io.Writer) error {
+	g.wordMap = make(map[string]struct{})
+	g.wordOrder = nil
+	g.wordFreq = make(map[string]float64)
+	g.wordSource = make(map[string]int)
+	g.groups = 0
+	g.groupHits = 0
+	g.wordArray = nil
+	g.proSign = nil
+	g.alerts = nil
+
+	//
+	// major flow decision - WORD_MODE or CODE_GROUPS ?
+	//
+	if g.opts.CodeGroups {
+		return g.makeGroups(w)
+	}
+
+	if g.opts.Callsigns {
+		return g.doOutput(g.makeCallsigns(), w)
+	}
+
+	if g.opts.QSO {
+		return g.doOutput(g.makeQSOs(), w)
+	}
+
+	if g.opts.Contest != "" {
+		return g.doOutput(g.makeExchanges(), w)
+	}
+
+	if g.opts.Numbers != "" && g.opts.NumMix == 0 {
+		return g.doOutput(g.makeNumbers(), w)
+	}
+
+	if g.opts.Radiogram {
+		words, err := g.makeRadiograms()
+		if err != nil {
+			return err
+		}
+		return g.doOutput(words, w)
+	}
+
+	if g.opts.Sentences {
+		words, err := g.makeSentences()
+		if err != nil {
+			return err
+		}
+		return g.doOutput(words, w)
+	}
+
+	if g.opts.NGram > 0 {
+		words, err := g.makeNGrams()
+		if err != nil {
+			return err
+		}
+		return g.doOutput(words, w)
+	}
+
+	words, err := g.readFileMode()
+	if err != nil {
+		return err
+	}
+
+	if g.opts.DitOrder != "" {
+		g.sortByDits(words)
+	}
+
+	if g.opts.NumMix > 0 {
+		words = g.mixNumbers(words)
+	}
+
+	return g.doOutput(words, w)
+}
+
+// setup does every range and compatibility check, collecting all the
+// problems found, then expands the list options
+func (g *Generator) setup() error {
+	o := &g.opts
+	var errs ValidationErrors
+	var err error
+
+	//
+	// out of range checks
+	if o.DM < 0 || o.DM > MaxDelimChars {
+		errs.add("DM", o.DM, "delimiter multiple min >=0, max <= %d", MaxDelimChars)
+	} else if o.DM >= 1 {
+		// ok DM is in range
+
+		// first make sure any prosign is valid format
+		m := regexp.MustCompile(`<[a-zA-Z]{2}>`)
+		tStr := m.ReplaceAllString(o.Delimiter, "")
+
+		if strings.Contains(tStr, "<") || strings.Contains(tStr, ">") {
+			errs.add("delimiter", o.Delimiter, "contains invalid prosign format")
+		} else {
+			// split into fields if any
+			for _, field := range strings.Split(o.Delimiter, "^") {
+				if err := g.processDelimiter(field); err != nil {
+					errs.addErr(err)
+				}
+			}
+		}
+	}
+
+	if o.Min < 1 {
+		errs.add("min", o.Min, "must be >= 1")
+	}
+
+	if o.Min > o.Max {
+		errs.add("min", o.Min, "must be <= max <%d>", o.Max)
+	}
+
+	if o.Max > MaxWordLen {
+		errs.add("max", o.Max, "must be <= <%d>, system max", MaxWordLen)
+	}
+
+	if o.CGMax < o.CGMin {
+		errs.add("cgmax", o.CGMax, "must be >= cgmin <%d>", o.CGMin)
+	}
+
+	if o.CGMin < 1 {
+		errs.add("cgmin", o.CGMin, "must be >= 1")
+	}
+
+	if o.MixedMode < 0 || o.MixedMode == 1 || o.MixedMode > MaxMixedMode {
+		errs.add("mixedMode", o.MixedMode, "minimum 2, maximum %d, default 0=off", MaxMixedMode)
+	}
+
+	if o.Skip < 0 || o.Skip > MaxSkips {
+		errs.add("skip", o.Skip, "minimum 0, maximum %d, default 0", MaxSkips)
+	}
+
+	if o.Num < 1 || o.Num > MaxUserWords {
+		errs.add("num", o.Num, "number of output words desired. minimum 1, maximum %d, default 100", MaxUserWords)
+	}
+
+	if o.Len < 1 || o.Len > MaxLineLen {
+		errs.add("len", o.Len, "max output line length, default 80, maximum %d", MaxLineLen)
+	}
+
+	if o.Suffix < 0 || o.Suffix > MaxSuffix {
+		errs.add("suffix", o.Suffix, "0=no suffix, max number of characters is %d", MaxSuffix)
+	}
+
+	if o.MixedMode > 0 && o.CodeGroups {
+		errs.add("mixedMode", o.MixedMode, "mutually exclusive with codeGroups option")
+	}
+
+	if o.Suffix > 0 {
+		if o.Suflist == "" {
+			errs.add("suflist", o.Suflist, "if suffix > 0, the suflist must contain characters, its empty")
+		} else if g.suflistRune, err = g.ckValidInString(o.Suflist, "suflist"); err != nil {
+			errs.addErr(err)
+		}
+	}
+
+	if o.Prefix < 0 || o.Prefix > MaxPrefix {
+		errs.add("prefix", o.Prefix, "0=no prefix, max number of characters is %d", MaxPrefix)
+	}
+
+	if o.Prefix > 0 {
+		if o.Prelist == "" {
+			errs.add("prelist", o.Prelist, "if prefix > 0, the prelist must contain characters, its empty")
+		} else if g.prelistRune, err = g.ckValidInString(o.Prelist, "prelist"); err != nil {
+			errs.addErr(err)
+		}
+	}
+
+	if o.Random && (o.Suffix == 0 && o.Prefix == 0) {
+		errs.add("random", o.Random, "requires either prefix > 0 or suffix > 0")
+	}
+
+	if o.Repeat < 1 || o.Repeat > MaxRepeat {
+		errs.add("repeat", o.Repeat, "must be between 1 and %d (default 1)", MaxRepeat)
+	}
+
+	if o.WordCount < 0 || o.WordCount > MaxWordCount {
+		errs.add("wordCount", o.WordCount, "must be between 1 and %d to link words as a phrase (default 0)", MaxWordCount)
+	}
+
+	if o.WordCount >= 1 && o.Repeat < 2 {
+		errs.add("wordCount", o.WordCount, "requires the repeat option >= 2")
+	}
+
+	if o.NR && (o.Unique || o.CodeGroups) {
+		errs.add("NR", o.NR, "mutually exclusive with unique and codeGroups options")
+	}
+
+	if modes := o.modes(); len(modes) > 1 {
+		names := []string{}
+		for _, m := range o.textModes() {
+			names = append(names, m.name)
+		}
+		errs.add(modes[1], true, "only one of %s can be used", strings.Join(names, ", "))
+	} else if o.NR && o.generated() && !o.Radiogram {
+		errs.add("NR", o.NR, "mutually exclusive with the %s option", modes[0])
+	}
+
+	if (o.Pseudo || o.NGram > 0) && (o.NR || o.Vocab != "") || o.Sentences && o.Vocab != "" {
+		errs.add(o.modes()[0], true, "only uses the in file, mutually exclusive with NR and vocab")
+	}
+
+	if o.NGram < 0 || o.NGram == 1 || o.NGram > MaxNGram {
+		errs.add("ngram", o.NGram, "characters in an n-gram, minimum 2, maximum %d, default 0=off", MaxNGram)
+	}
+
+	if o.NGramTop < 1 || o.NGramTop > MaxNGramTop {
+		errs.add("ngramTop", o.NGramTop, "number of the most frequent n-grams to drill, minimum 1, maximum %d", MaxNGramTop)
+	}
+
+	if o.NGramGroups && o.NGram == 0 {
+		errs.add("ngramGroups", o.NGramGroups, "requires the ngram option")
+	}
+
+	if o.NGramGroups && o.CGMax < o.NGram {
+		errs.add("cgmax", o.CGMax, "must be >= ngram <%d> to fit the n-gram in a group", o.NGram)
+	}
+
+	if o.QSO && o.Num > MaxQSOs {
+		errs.add("num", o.Num, "with qso, the number of QSOs, maximum %d", MaxQSOs)
+	}
+
+	if o.Radiogram && o.Num > MaxRadiograms {
+		errs.add("num", o.Num, "with radiogram, the number of messages, maximum %d", MaxRadiograms)
+	}
+
+	if o.RGWords < 0 || o.RGWords > MaxRGWords {
+		errs.add("rgWords", o.RGWords, "words of radiogram text, 0(5 to 25 at random), maximum %d", MaxRGWords)
+	}
+
+	if o.Contest != "" {
+		o.Contest = strings.ToUpper(o.Contest)
+		if _, ok := contests[o.Contest]; !ok {
+			errs.add("contest", o.Contest, "choices are (case insensitive): %s", strings.Join(ContestNames(), ", "))
+		}
+	}
+
+	if o.Cut && o.Contest == "" {
+		errs.add("cut", o.Cut, "requires the contest option")
+	}
+
+	g.setupSources(&errs)
+
+	// plain words come from the built-in dictionary without an input file
+	if (o.Sentences || o.NGram > 0) && o.Input == "" {
+		errs.add("in", o.Input, "an input file must be given for -sentences or -ngram")
+	}
+
+	g.setupVocab(&errs)
+	g.setupDict(&errs)
+
+	// ebook options
+	// hard code some values since they are arbitrary
+
+	if o.EBNum < 0 || o.EBNum > 30 {
+		errs.add("EB_NUM", o.EBNum, "number of speed values must be >= 0 and <= 30")
+	}
+
+	if o.EBNum > 0 {
+		if o.EBSlow > 0 || o.EBFast > 0 {
+			errs.add("EB_NUM", o.EBNum, "must = 0(off) if EB_SLOW > 0")
+		}
+
+		if o.EBRepeat > 1 && !o.EBRamp {
+			errs.add("EB_REPEAT", o.EBRepeat, "mutually exclusive with EB_NUM unless also with EB_RAMP")
+		}
+	}
+
+	if o.EBLow < 5 {
+		errs.add("EB_LOW", o.EBLow, "lowest speed must be at least 5 wpm")
+	}
+
+	if o.EBEff > 0 {
+		if o.EBEff >= o.EBLow {
+			errs.add("EB_EFFECTIVE", o.EBEff, "speed must be < EB_LOW <%d> wpm", o.EBLow)
+		}
+
+		// set delta since eblow and eff are set
+		g.effDelta = o.EBLow - o.EBEff
+	}
+
+	if o.EBStep < 0 || o.EBStep > 20 {
+		errs.add("EB_STEP", o.EBStep, "speed incremental step must be >= 0 and <= 20 wpm, 0(off)")
+	}
+
+	if o.EBSlow < 0 {
+		errs.add("EB_SLOW", o.EBSlow, "must be >= 0, 0(off)")
+	}
+
+	if o.EBFast < 0 {
+		errs.add("EB_FAST", o.EBFast, "must be >= 0, 0(off)")
+	}
+
+	if o.EBFast > 0 && o.EBSlow == 0 {
+		errs.add("EB_FAST", o.EBFast, "requires EB_SLOW to be specified")
+	}
+
+	// we want EB, lots of exclusions to try
+	if o.EBSlow > 0 {
+		if o.EBFast == 0 {
+			o.EBFast = o.EBSlow
+		}
+
+		if o.EBStep < 1 {
+			errs.add("EB_STEP", o.EBStep, "must be >=1 with EB_SLOW/EB_FAST")
+		}
+
+		if o.EBRepeat >= 1 {
+			errs.add("EB_REPEAT", o.EBRepeat, "mutually exclusive with EB_SLOW and EB_FAST options")
+		}
+	}
+
+	if o.EBRepeat < 0 || o.EBRepeat > 30 {
+		errs.add("EB_REPEAT", o.EBRepeat, "must be >=2 and <= 30 for word speed repeat, 0(off)")
+	}
+
+	if o.EBRamp {
+		if o.EBFast > 0 {
+			errs.add("EB_RAMP", o.EBRamp, "mutually exclusive with EB_SLOW and EB_FAST options")
+		}
+
+		if o.EBNum == 0 {
+			errs.add("EB_RAMP", o.EBRamp, "requires EB_NUM > 0")
+		}
+
+		if o.EBStep == 0 {
+			errs.add("EB_RAMP", o.EBRamp, "requires EB_STEP > 0")
+		}
+	}
+
+	if o.EBEffRamp {
+		if o.EBRepeat > 0 {
+			errs.add("EB_EFFECTIVE_RAMP", o.EBEffRamp, "mutually exclusive with EB_REPEAT")
+		}
+
+		if o.EBNum == 0 {
+			errs.add("EB_EFFECTIVE_RAMP", o.EBEffRamp, "requires EB_NUM > 0")
+		}
+
+		if o.EBStep < 1 {
+			errs.add("EB_EFFECTIVE_RAMP", o.EBEffRamp, "requires EB_STEP >= 1 and its less than EB_LOW")
+		}
+
+		if o.EBRamp {
+			errs.add("EB_EFFECTIVE_RAMP", o.EBEffRamp, "mutually exclusive with EB_RAMP")
+		}
+	}
+
+	if (o.EBRamp || o.EBEffRamp) && o.EBNum > 0 && !o.QSO && o.Num/o.EBNum < 1 {
+		errs.add("EB_NUM", o.EBNum, "too large for the -num value <%d>, there would not be any words in each speed change section", o.Num)
+	}
+
+	if o.WavWPM < 5 || o.WavWPM > MaxWPM {
+		errs.add("wavWPM", o.WavWPM, "must be >= 5 and <= %d wpm", MaxWPM)
+	}
+
+	if o.WavEff < 0 || o.WavEff > o.WavWPM {
+		errs.add("wavEff", o.WavEff, "must be >= 0(off) and <= wavWPM <%d>", o.WavWPM)
+	}
+
+	if o.WavFreq < 200 || o.WavFreq > 2000 {
+		errs.add("wavFreq", o.WavFreq, "tone must be >= 200 and <= 2000 Hz")
+	}
+
+	g.setupLesson(&errs)
+	g.setupEmphasis(&errs)
+
+	// check inlist for %XX codes
+	if o.Lesson == 0 {
+		o.Inlist = koch.ExpandPercent(o.Inlist)
+	}
+
+	if !o.CodeGroups && (!o.generated() || o.Input != "") {
+		if o.Inlist == "" {
+			errs.add("inlist", o.Inlist, "can't be empty or nothing gets matched")
+		} else if _, err := regexp.Compile("[" + o.Inlist + "]"); err != nil {
+			errs.add("inlist", o.Inlist, "not a valid set of characters")
+		}
+	}
+
+	// must follow other cglist manipulation
+	// either case lets get cglist expanded now
+	if o.CodeGroups || o.MixedMode > 0 || o.Callsigns || o.NGramGroups || o.Sentences || o.Vocab != "" {
+		// make sure we have chars to work with
+		if len(o.Cglist) < 2 {
+			errs.add("cglist", o.Cglist, "you requested codeGroups, mixedMode, callsigns, sentences or vocab, so cglist must have at least 2 characters")
+		} else if g.cglistRune, err = g.ckValidInString(o.Cglist, "cglist"); err != nil {
+			errs.addErr(err)
+		} else if o.Callsigns {
+			g.setupCallsigns(&errs, g.cglistRune)
+		}
+	}
+
+	// a QSO, contest or radiogram has any call, the lesson is for the rest of the text
+	if o.QSO || o.Contest != "" || o.Radiogram {
+		g.setupCallsigns(&errs, []rune("abcdefghijklmnopqrstuvwxyz0123456789/"))
+	}
+
+	g.setupWeights(&errs)
+	g.setupProfile(&errs)
+	g.setupConfusable(&errs)
+	g.setupDits(&errs)
+	g.setupNumbers(&errs)
+	g.setupRank(&errs)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// setupLesson fills cglist and inlist from the lesson number of the tutor
+func (g *Generator) setupLesson(errs *ValidationErrors) {
+	o := &g.opts
+
+	o.Tutor = strings.ToUpper(o.Tutor)
+	if o.Tutor == "" {
+		o.Tutor = "LCWO"
+	}
+
+	if o.Lesson < 0 {
+		errs.add("lesson", o.Lesson, "must be >= 0, 0(off)")
+		return
+	}
+
+	if o.Lesson == 0 {
+		if o.Tutor != "LCWO" {
+			errs.add("tutor", o.Tutor, "lesson = 0 is invalid for this tutor")
+		}
+		return
+	}
+
+	tutor, ok := koch.FindTutor(o.Tutor)
+	if !ok {
+		errs.add("tutor", o.Tutor, "name is invalid. Names are NOT case sensitive, and without any spaces, see the help")
+		return
+	}
+
+	chars, err := tutor.Lesson(o.Lesson)
+	if err != nil {
+		errs.add("lesson", o.Lesson, "%v", err)
+		return
+	}
+
+	// cglist is LC, inlist is mixed case
+	o.Cglist = chars
+	o.Inlist = koch.Inlist(chars)
+
+	if o.Caps {
+		o.Cglist = strings.ToUpper(o.Cglist)
+	}
+}
@@ -0,0 +1,209 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the testdata golden files")
+
+// the practice text of the tests, read from testdata
+const testText = "testdata/text.txt"
+
+// testOptions are the defaults with a fixed seed, so the text repeats
+func testOptions() Options {
+	opts := DefaultOptions()
+	opts.Seed = 1
+
+	return opts
+}
+
+// generate returns the text of opts, failing the test on an error
+func generate(t *testing.T, opts Options) string {
+	t.Helper()
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	text, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	return text
+}
+
+// checkGolden compares got with testdata/name.golden, or rewrites it with -update
+func checkGolden(t *testing.T, name string, got string) {
+	t.Helper()
+	file := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		if err := os.WriteFile(file, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("%v, run go test -update to make it", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("%s: text changed, got:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestGenerateGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(o *Options)
+	}{
+		{"words", func(o *Options) {
+			o.Input = testText
+			o.Num = 30
+		}},
+		{"words_caps_len", func(o *Options) {
+			o.Input = testText
+			o.Num = 20
+			o.Min = 4
+			o.Max = 7
+			o.Caps = true
+			o.Len = 30
+		}},
+		{"words_nr_skip", func(o *Options) {
+			o.Input = testText
+			o.Num = 12
+			o.NR = true
+			o.Skip = 3
+		}},
+		{"words_prefix_suffix", func(o *Options) {
+			o.Input = testText
+			o.Num = 15
+			o.Prefix = 2
+			o.Suffix = 1
+			o.Prelist = "0-9"
+			o.Suflist = "?/"
+		}},
+		{"words_delimiter_repeat", func(o *Options) {
+			o.Input = testText
+			o.Num = 10
+			o.Delimiter = "<BT>^de^1-3"
+			o.DM = 2
+			o.Repeat = 2
+		}},
+		{"code_groups", func(o *Options) {
+			o.CodeGroups = true
+			o.Num = 20
+			o.Cglist = "a-f0-3?"
+		}},
+		{"mixed_mode", func(o *Options) {
+			o.Input = testText
+			o.Num = 20
+			o.MixedMode = 4
+			o.Cglist = "kmr"
+			o.EBSF = "<BT>"
+			o.EBFS = "<AR>"
+		}},
+		{"lesson", func(o *Options) {
+			o.Input = testText
+			o.Num = 20
+			o.Lesson = 12
+		}},
+		{"ebook_repeat", func(o *Options) {
+			o.Input = testText
+			o.Num = 8
+			o.EBRepeat = 2
+			o.EBLow = 15
+			o.EBStep = 5
+		}},
+		{"header_seed", func(o *Options) {
+			o.Input = testText
+			o.Num = 5
+			o.Header = "vvv"
+			o.SeedHeader = true
+		}},
+	}
+
+	for _, tt := range tests {
+		opts := testOptions()
+		tt.set(&opts)
+		checkGolden(t, tt.name, generate(t, opts))
+	}
+}
+
+func TestGenerateSeed(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Num = 50
+
+	first := generate(t, opts)
+	if again := generate(t, opts); again != first {
+		t.Errorf("the same seed made different text:\n%s\n%s", first, again)
+	}
+
+	opts.Seed = 2
+	if other := generate(t, opts); other == first {
+		t.Errorf("seeds 1 and 2 made the same text:\n%s", first)
+	}
+}
+
+func TestGenerateTo(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Num = 25
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the same seed again, GenerateTo must write what Generate returned
+	g, err = New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sb strings.Builder
+	if err := g.GenerateTo(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	if sb.String() != text {
+		t.Errorf("GenerateTo wrote:\n%s\nGenerate returned:\n%s", sb.String(), text)
+	}
+}
+
+func TestGenerateNothingToOutput(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "short.txt")
+	if err := os.WriteFile(file, []byte("a an the and"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := testOptions()
+	opts.Input = file
+	opts.Min = 5
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.Generate(); !errors.Is(err, ErrNothingToOutput) {
+		t.Errorf("no word is 5 long, got error %v, want ErrNothingToOutput", err)
+	}
+}
@@ -0,0 +1,112 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/wa2nfn/cwpt/koch"
+)
+
+// make sure the string can be expanded into visable ASCII since all morse is limited to that
+func (g *Generator) ckValidInString(ck string, whoAmI string) ([]rune, error) {
+	str, err := koch.ExpandRanges(ck)
+	if err != nil {
+		return nil, listError(whoAmI, ck, "%v", err)
+	}
+
+	// check each rune to make sure its valid
+	// also build a new string that is in the proper case
+	newRune := []rune{}
+
+	gotEscSymbol := false
+	strInternational := ""
+
+	for _, runeRead := range []rune(str) {
+		if whoAmI != "delimiter" && runeRead == '*' {
+			return nil, listError(whoAmI, ck, "invalid character <%s>, only used in delimiter option, as a special case delay for LCWO/ebook2cw users", string(runeRead))
+		}
+
+		if runeRead == '%' && gotEscSymbol == false {
+
+			// we potenially have an international
+			gotEscSymbol = true
+			continue
+		}
+
+		if gotEscSymbol == true && len(strInternational) < 2 {
+			if runeRead == '%' {
+				// two %s NG
+				return nil, listError(whoAmI, ck, "invalid <%%>, not followed by appropriate 2 upper case letters")
+			}
+
+			// need to get two proper upper case
+			strInternational += string(runeRead)
+			if len(strInternational) < 2 {
+				continue
+			}
+
+			runeRead = koch.International(strInternational)
+			// reset these two
+			gotEscSymbol = false
+			strInternational = ""
+		}
+
+		// prosigns or a space may be in a delimiter field
+		if !koch.Valid(runeRead) && !(whoAmI == "delimiter" && koch.DelimiterOnly(runeRead)) {
+			return nil, listError(whoAmI, ck, "invalid entry <%v>", string(runeRead))
+		}
+
+		if g.opts.Caps {
+			newRune = append(newRune, unicode.ToUpper(runeRead))
+		} else {
+			newRune = append(newRune, unicode.ToLower(runeRead))
+		}
+	}
+
+	if whoAmI == "delimiter" {
+		s := string(newRune)
+		s = strings.ReplaceAll(s, "*", " |S500 ")
+		g.delimiterSlice = append(g.delimiterSlice, s)
+		newRune = nil
+	}
+
+	return newRune, nil
+}
+
+// called for each field of a delimiter option
+func (g *Generator) processDelimiter(inStr string) error {
+	// eliminate special case of simple range, each char is its own delimiter
+	m := regexp.MustCompile("^([0-9]-[0-9])|([a-z]-[a-z])|([A-Z]-[A-Z])$")
+	if m.MatchString(inStr) {
+		s, err := koch.ExpandRange(string(inStr[0]), string(inStr[2]))
+		if err != nil {
+			return listError("delimiter", inStr, "%v", err)
+		}
+
+		for _, r := range s {
+			g.delimiterSlice = append(g.delimiterSlice, string(r))
+		}
+		return nil
+	}
+
+	_, err := g.ckValidInString(inStr, "delimiter")
+	return err
+}
+
+// sendable is true of a character of inlist or cglist (both the lesson if given)
+func (g *Generator) sendable() func(r rune) bool {
+	inlist := regexp.MustCompile("[" + g.opts.Inlist + "]")
+	cglist := make(map[rune]bool)
+	for _, r := range g.cglistRune {
+		cglist[unicode.ToLower(r)] = true
+	}
+
+	return func(r rune) bool {
+		return cglist[unicode.ToLower(r)] || inlist.MatchString(string(r))
+	}
+}
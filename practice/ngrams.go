@@ -0,0 +1,114 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wa2nfn/cwpt/koch"
+)
+
+// makeNGrams ranks the character n-grams of the input file, then returns num
+// of the most frequent, alone or each in a random group
+func (g *Generator) makeNGrams() ([]string, error) {
+	ranked, err := g.rankNGrams()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ranked) == 0 {
+		return nil, g.nothingToOutput(false, false)
+	}
+
+	if len(ranked) > g.opts.NGramTop {
+		ranked = ranked[:g.opts.NGramTop]
+	}
+
+	// each of the top n-grams as often as the others
+	g.wordOrder = ranked
+	g.shuffle(g.wordOrder)
+	drill := g.fillArray()
+
+	if g.opts.NGramGroups {
+		for i, ng := range drill {
+			drill[i] = g.ngramGroup(ng)
+		}
+	}
+
+	return drill, nil
+}
+
+// rankNGrams counts each n-gram inside the runs of inlist characters of the
+// input, most frequent first
+func (g *Generator) rankNGrams() ([]string, error) {
+	file, closeFiles, err := g.openInput()
+	if err != nil {
+		return nil, err
+	}
+	defer closeFiles()
+
+	run := regexp.MustCompile("[" + g.opts.Inlist + "]+")
+	counts := make(map[string]int)
+	ranked := []string{}
+
+	scanner := koch.NewScanner(file)
+	for scanner.Scan() {
+		for _, word := range koch.Words(scanner.Text()) {
+			for _, r := range run.FindAllString(word, -1) {
+				if g.opts.Caps {
+					r = strings.ToUpper(r)
+				} else {
+					r = strings.ToLower(r)
+				}
+
+				runes := []rune(r)
+				for i := 0; i+g.opts.NGram <= len(runes); i++ {
+					ng := string(runes[i : i+g.opts.NGram])
+					if counts[ng] == 0 {
+						ranked = append(ranked, ng)
+					}
+					counts[ng]++
+				}
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return counts[ranked[i]] > counts[ranked[j]]
+	})
+
+	return ranked, nil
+}
+
+// ngramGroup puts ng at a random place in a group of cglist characters
+func (g *Generator) ngramGroup(ng string) string {
+	n := len([]rune(ng))
+	gl := g.opts.CGMin
+	if g.opts.CGMax != g.opts.CGMin {
+		gl = g.rng.Intn(g.opts.CGMax-g.opts.CGMin+1) + g.opts.CGMin
+	}
+	if gl < n {
+		gl = n
+	}
+
+	at := g.rng.Intn(gl - n + 1)
+	group := []rune{}
+
+	for len(group) < at {
+		group = append(group, g.pickRune(g.cglistRune))
+	}
+	group = append(group, []rune(ng)...)
+	for len(group) < gl {
+		group = append(group, g.pickRune(g.cglistRune))
+	}
+
+	return string(group)
+}
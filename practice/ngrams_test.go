@@ -0,0 +1,83 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNGram(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.NGram = 2
+	opts.NGramTop = 10
+	opts.Num = 30
+
+	checkGolden(t, "ngram", generate(t, opts))
+}
+
+// ngramFile is a temp in file of text
+func ngramFile(t *testing.T, text string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "in.txt")
+	if err := os.WriteFile(file, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+func TestNGramRank(t *testing.T) {
+	opts := testOptions()
+	opts.Input = ngramFile(t, "The the, THE then.\nsing ring 73 x")
+	opts.NGram = 3
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// most frequent first, a tie in the order first read
+	got, err := g.rankNGrams()
+	want := []string{"the", "ing", "hen", "sin", "rin"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("rankNGrams = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestNGramDrill(t *testing.T) {
+	opts := testOptions()
+	opts.Input = ngramFile(t, "the the the then sing ring")
+	opts.NGram = 3
+	opts.NGramTop = 2
+	opts.Num = 10
+
+	counts := make(map[string]int)
+	for _, w := range strings.Fields(generate(t, opts)) {
+		counts[w]++
+	}
+
+	// each of the top n-grams as often as the others
+	if want := map[string]int{"the": 5, "ing": 5}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("drill %v, want %v", counts, want)
+	}
+
+	opts.NGramGroups = true
+	opts.Cglist = "0-9"
+	opts.CGMin = 4
+	opts.CGMax = 6
+
+	for _, w := range strings.Fields(generate(t, opts)) {
+		ng := strings.Trim(w, "0123456789")
+		if (ng != "the" && ng != "ing") || len(w) < opts.CGMin || len(w) > opts.CGMax {
+			t.Errorf("group %s, want the or ing in %d to %d characters", w, opts.CGMin, opts.CGMax)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// number drills, name to the maker of one
+var numberKinds = map[string]func(g *Generator) string{
+	"time": func(g *Generator) string { return fmt.Sprintf("%02d%02dz", g.rng.Intn(24), g.rng.Intn(60)) },
+	"date": func(g *Generator) string {
+		m, d, y := 1+g.rng.Intn(12), 1+g.rng.Intn(28), 2000+g.rng.Intn(30)
+		if g.rng.Intn(2) == 0 {
+			return fmt.Sprintf("%02d/%02d/%02d", m, d, y%100)
+		}
+		return fmt.Sprintf("%d%s%d", d, rgMonths[m-1], y)
+	},
+	"freq": func(g *Generator) string {
+		band := g.pick(cwBands)
+		var mhz, khz int
+		fmt.Sscanf(band, "%d.%d", &mhz, &khz)
+		khz += g.rng.Intn(60)
+		if g.rng.Intn(3) == 0 {
+			// in kHz
+			return fmt.Sprintf("%d", mhz*1000+khz)
+		}
+		return fmt.Sprintf("%d.%03d", mhz, khz)
+	},
+	"power": func(g *Generator) string { return g.pick(powerLevels) },
+	"rst": func(g *Generator) string {
+		return fmt.Sprintf("%d%d%d", 3+g.rng.Intn(3), 3+g.rng.Intn(7), 7+g.rng.Intn(3))
+	},
+	"zip": func(g *Generator) string {
+		if g.rng.Intn(4) == 0 {
+			return fmt.Sprintf("%05d-%04d", g.rng.Intn(100000), g.rng.Intn(10000))
+		}
+		return fmt.Sprintf("%05d", g.rng.Intn(100000))
+	},
+	"grid": func(g *Generator) string {
+		return fmt.Sprintf("%c%c%d%d%c%c", 'a'+g.rng.Intn(18), 'a'+g.rng.Intn(18), g.rng.Intn(10), g.rng.Intn(10),
+			'a'+g.rng.Intn(24), 'a'+g.rng.Intn(24))
+	},
+}
+
+var (
+	// bottom of the CW end of each band, MHz.kHz
+	cwBands     = []string{"1.800", "3.500", "7.000", "10.100", "14.000", "18.068", "21.000", "24.890", "28.000"}
+	powerLevels = []string{"1w", "5w", "10w", "25w", "50w", "100w", "150w", "200w", "400w", "500w", "1kw", "1500w"}
+)
+
+// NumberKinds returns the -numbers choices, all is every kind.
+func NumberKinds() []string {
+	names := make([]string, 0, len(numberKinds))
+	for name := range numberKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// setupNumbers checks the -numbers kinds and -numMix
+func (g *Generator) setupNumbers(errs *ValidationErrors) {
+	o := &g.opts
+
+	if o.NumMix < 0 || o.NumMix == 1 || o.NumMix > MaxMixedMode {
+		errs.add("numMix", o.NumMix, "minimum 2, maximum %d, default 0=off", MaxMixedMode)
+	}
+
+	if o.Numbers == "" {
+		if o.NumMix > 0 {
+			errs.add("numMix", o.NumMix, "requires the numbers option")
+		}
+		return
+	}
+
+	if o.NumMix > 0 && (o.CodeGroups || o.generated() || o.Sentences || o.NGram > 0) {
+		errs.add("numMix", o.NumMix, "mixes numbers with words from the in file (or vocab), not with the other modes")
+	}
+
+	o.Numbers = strings.ToLower(o.Numbers)
+	g.numberKinds = []string{}
+
+	for _, name := range strings.Split(o.Numbers, ",") {
+		name = strings.TrimSpace(name)
+
+		if name == "all" {
+			g.numberKinds = append(g.numberKinds, NumberKinds()...)
+			continue
+		}
+
+		if _, ok := numberKinds[name]; !ok {
+			errs.add("numbers", o.Numbers, "kind <%s> is invalid, choices are a comma list of: %s, or all", name, strings.Join(NumberKinds(), ", "))
+			continue
+		}
+
+		g.numberKinds = append(g.numberKinds, name)
+	}
+}
+
+// number makes one number of a random -numbers kind
+func (g *Generator) number() string {
+	n := numberKinds[g.pick(g.numberKinds)](g)
+
+	if g.opts.Caps {
+		return strings.ToUpper(n)
+	}
+
+	return n
+}
+
+// makeNumbers returns num numbers, to be output like words
+func (g *Generator) makeNumbers() []string {
+	numbers := make([]string, 0, g.opts.Num)
+
+	for i := 0; i < g.opts.Num; i++ {
+		numbers = append(numbers, g.number())
+	}
+
+	return numbers
+}
+
+// mixNumbers puts a number after every -numMix words
+func (g *Generator) mixNumbers(words []string) []string {
+	mixed := make([]string, 0, len(words)+len(words)/g.opts.NumMix)
+
+	for i, w := range words {
+		mixed = append(mixed, w)
+
+		if (i+1)%g.opts.NumMix == 0 {
+			mixed = append(mixed, g.number())
+		}
+	}
+
+	return mixed
+}
@@ -0,0 +1,67 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestNumbers(t *testing.T) {
+	opts := testOptions()
+	opts.Numbers = "all"
+	opts.Num = 20
+
+	checkGolden(t, "numbers", generate(t, opts))
+}
+
+func TestNumberKinds(t *testing.T) {
+	kinds := map[string]*regexp.Regexp{
+		"time":  regexp.MustCompile(`^([01][0-9]|2[0-3])[0-5][0-9]z$`),
+		"date":  regexp.MustCompile(`^((0[1-9]|1[0-2])/[0-2][0-9]/[0-9]{2}|[1-9][0-9]?(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)20[0-2][0-9])$`),
+		"freq":  regexp.MustCompile(`^([0-9]{1,2}\.[0-9]{3}|[0-9]{4,5})$`),
+		"power": regexp.MustCompile(`^[0-9]+k?w$`),
+		"rst":   regexp.MustCompile(`^[3-5][3-9][7-9]$`),
+		"zip":   regexp.MustCompile(`^[0-9]{5}(-[0-9]{4})?$`),
+		"grid":  regexp.MustCompile(`^[a-r]{2}[0-9]{2}[a-x]{2}$`),
+	}
+
+	if len(kinds) != len(NumberKinds()) {
+		t.Fatalf("kinds %v, want a test of each", NumberKinds())
+	}
+
+	for kind, re := range kinds {
+		opts := testOptions()
+		opts.Numbers = strings.ToUpper(kind)
+		opts.Num = 200
+
+		for _, n := range strings.Fields(generate(t, opts)) {
+			if !re.MatchString(n) {
+				t.Errorf("%s: %s", kind, n)
+			}
+		}
+	}
+}
+
+func TestNumMix(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Numbers = "rst, zip"
+	opts.NumMix = 3
+	opts.Num = 30
+
+	number := regexp.MustCompile(`^[0-9-]+$`)
+	words := strings.Fields(generate(t, opts))
+	if len(words) != opts.Num+opts.Num/opts.NumMix {
+		t.Fatalf("%d words, want %d and a number after every %d", len(words), opts.Num, opts.NumMix)
+	}
+
+	for i, w := range words {
+		if want := (i+1)%(opts.NumMix+1) == 0; number.MatchString(w) != want {
+			t.Errorf("word %d %s, a number is %v", i, w, want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import "io"
+
+// system limits, these are arbitrary but keep the output sane
+const (
+	MaxWordLen    = 40
+	MaxUserWords  = 10000
+	MaxLineLen    = 500
+	MaxSuffix     = 20
+	MaxPrefix     = 20
+	MaxDelimChars = 20
+	MaxRepeat     = 20
+	MaxSkips      = 5000
+	MaxMixedMode  = 20
+	MaxWordCount  = 5
+	MaxWPM        = 80
+	MaxQSOs       = 100
+	MaxRadiograms = 100
+	MaxRGWords    = 50
+	MaxNGram      = 5
+	MaxNGramTop   = 1000
+	MaxConfusable = 20
+	InListStr     = "A-Za-z%C0%E0%C4%E4%C9%E9%C8%E8%C7%E7%D1%F1%D6%F6%DC%FC"
+	//inListStr     = "A-Za-zÀàÄäÉéÈèÇçÑñÖöÜü"
+)
+
+// Options holds everything that controls the generated practice text.
+// Each field but Stdin matches the cwpt2 command line option of the same name.
+// Start from DefaultOptions and change only what you need.
+type Options struct {
+	Max           int // max characters in a word
+	CGMax         int // max characters in a code group
+	Min           int // min characters in a word (or code group)
+	CGMin         int // min characters in a code group
+	Repeat        int // times to repeat each word sequentially
+	Num           int // number of words (or code groups) to output
+	Len           int // output line length
+	Skip          int // unique words in the input to skip
+	Suffix        int // max suffix characters to append to a word
+	Prefix        int // max prefix characters to affix to a word
+	DM            int // delimiter multiple
+	DR            bool
+	Lesson        int
+	Emphasis      int // percent of code groups and words with one of the newest lesson characters, 0 is off
+	EmphasisChars int // how many of the newest lesson characters to emphasize
+	MixedMode     int
+	WordCount     int
+	Header        string
+	Prelist       string
+	Suflist       string
+	Inlist        string
+	Cglist        string
+	Input         string    // input text file name, the built-in Dict words if empty
+	Stdin         io.Reader // read for an Input source of "-", nil if there is none
+	Prosign       string    // prosign file name
+	Dict          string    // built-in word list used without Input, see Dicts
+	Vocab         string    // built-in vocabulary sets, see VocabSets, with or instead of Input
+	VocabRatio    int       // percent of words from Vocab when Input is also given
+	Delimiter     string
+	Tutor         string
+	Caps          bool
+	Random        bool
+	Unique        bool
+	NR            bool
+	MMR           bool
+	CodeGroups    bool
+	Callsigns     bool   // random amateur callsigns from the prefix table, made of cglist characters
+	QSO           bool   // num ragchew QSOs made from templates
+	Contest       string // num exchanges of this contest, see ContestNames
+	Cut           bool   // contest cut numbers, 0 is t and 9 is n
+	Numbers       string // formatted number kinds, see NumberKinds
+	NumMix        int    // a number after every NumMix words, 0 is numbers only
+	Radiogram     bool   // num NTS radiograms, the text from Input if given
+	RGWords       int    // words of radiogram text, 0 is 5 to 25 at random
+	Reverse       bool
+	Pseudo        bool   // made up words, with the character odds of the input words
+	Sentences     bool   // whole sentences of the input, keeping the punctuation that can be sent
+	NGram         int    // drill the most frequent character n-grams of this length in the input
+	NGramTop      int    // how many of the most frequent n-grams
+	NGramGroups   bool   // each n-gram inside a random group of cglist characters
+	Confusable    int    // drill this many of the most alike sounding character pairs
+	MinDits       int    // shortest word to send, in dit units, 0 is off
+	MaxDits       int    // longest word to send, in dit units, 0 is off
+	DitOrder      string // "asc" or "desc" to sort the words by how long they take to send
+	Rank          string // choose words by frequency, see Ranks
+	RankFrom      int    // first rank of a "band", 1 is the most frequent word
+	RankTo        int    // last rank of a "band"
+	FreqList      string // file of "word [count]" lines to rank by, instead of the input's counts
+	Seed          int64  // random seed, 0 picks one from the clock
+	SeedHeader    bool   // add the seed to the header so the session can be made again
+	Progress      string // per character progress file, kept by the copy scoring
+	Weak          bool   // draw code group, prefix and suffix characters toward the weakest in Progress
+	Profile       string // how often each code group character is used, see Profiles
+	ProfileFile   string // char:weight lines for Profile "file"
+
+	// ebook2cw (or LCWO) speed options
+	EBSF      string
+	EBFS      string
+	EBStep    int
+	EBNum     int
+	EBSlow    int
+	EBLow     int
+	EBFast    int
+	EBRepeat  int
+	EBEff     int
+	EBRamp    bool
+	EBEffRamp bool
+
+	// WAV audio, the EB_ speed commands in the text override these
+	WavWPM  int // character speed
+	WavEff  int // effective (aka Farnsworth) speed, 0 is off
+	WavFreq int // tone in Hz
+}
+
+// DefaultOptions returns the same defaults the cwpt2 command uses.
+func DefaultOptions() Options {
+	return Options{
+		Max:           10,
+		CGMax:         5,
+		Min:           1,
+		CGMin:         5,
+		Repeat:        1,
+		Num:           100,
+		Len:           80,
+		Suflist:       "0-9,.?/=",
+		Prelist:       "0-9,.?/=",
+		Inlist:        InListStr,
+		Cglist:        "a-z0-9.,?/=",
+		Tutor:         "LCWO",
+		Dict:          "all",
+		Progress:      "cwpt2.progress",
+		VocabRatio:    25,
+		Profile:       "uniform",
+		NGramTop:      20,
+		EmphasisChars: 1,
+		EBLow:         15,
+		EBStep:        5,
+		WavWPM:        20,
+		WavFreq:       600,
+	}
+}
+
+// a text mode is an option that chooses a text other than plain words
+// from the input file, only one can be used
+type textMode struct {
+	name string
+	on   bool
+}
+
+func (o *Options) textModes() []textMode {
+	return []textMode{
+		{"codeGroups", o.CodeGroups},
+		{"callsigns", o.Callsigns},
+		{"qso", o.QSO},
+		{"contest", o.Contest != ""},
+		{"radiogram", o.Radiogram},
+		{"numbers", o.Numbers != "" && o.NumMix == 0},
+		{"pseudo", o.Pseudo},
+		{"sentences", o.Sentences},
+		{"ngram", o.NGram > 0},
+	}
+}
+
+// modes returns the option name of each text mode chosen, more than one is an error
+func (o *Options) modes() []string {
+	modes := []string{}
+
+	for _, m := range o.textModes() {
+		if m.on {
+			modes = append(modes, m.name)
+		}
+	}
+
+	return modes
+}
+
+// ReadsInput reports if the text is made from the words of an input, so
+// text piped to the command can be used without -in.
+func (o *Options) ReadsInput() bool {
+	return !o.CodeGroups && !o.generated()
+}
+
+// generated is true for a mode that makes its own words, no input file needed
+func (o *Options) generated() bool {
+	return o.Callsigns || o.QSO || o.Contest != "" || o.Radiogram || o.Numbers != "" && o.NumMix == 0
+}
@@ -0,0 +1,521 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+// returns a single random prefix/suffix from list to add to output word
+func (g *Generator) ixStr(ps string) string {
+	retStr := ""
+
+	if ps == "s" {
+		// user wants a suffix
+		for count := 1; count <= g.rng.Intn(g.opts.Suffix)+1; count++ {
+			retStr += string(g.pickRune(g.suflistRune))
+		}
+
+	} else {
+		// user wants a prefix
+		for count := 1; count <= g.rng.Intn(g.opts.Prefix)+1; count++ {
+			retStr += string(g.pickRune(g.prelistRune))
+		}
+	}
+
+	return retStr
+}
+
+// ready to print the users practice word
+func (g *Generator) doOutput(words []string, w io.Writer) error {
+	strBuf := ""
+	strOut := ""
+	// for eb options
+	firstSlowFast := true
+	lastSpeed := g.opts.EBLow
+	lastSpeedEff := g.opts.EBEff
+	counter := 1
+	sectionSize := 0
+	EBspeeds := []int{}
+	EBspeedsRepeat := []int{}
+	ebslowcnt := 0
+	ebfastcnt := 0
+	ebinslow := false
+	fBOUNCE := false
+	fSLOWFAST := false
+	fRAMP := false
+	fEFFRAMP := false
+	fREPEAT := false
+	speedCount := 0
+	var charSlice []rune
+
+	// num is usually the -num option, but not for a generated text like a QSO
+	num := len(words)
+
+	if g.opts.MixedMode > 0 {
+		charSlice = g.buildCharSlice(num)
+	}
+
+	// header
+	strOut += g.header()
+
+	// for runtime eff
+	if g.opts.EBRamp {
+		fRAMP = true
+	} else if g.opts.EBEffRamp {
+		fEFFRAMP = true
+	} else if g.opts.EBSlow > 0 {
+		fSLOWFAST = true
+	} else if g.opts.EBNum > 0 && g.opts.EBLow > 0 && g.opts.EBStep >= 1 && !fRAMP && !fSLOWFAST && !fEFFRAMP && !fREPEAT {
+		fBOUNCE = true
+	}
+
+	// for runtime eff
+	if g.opts.EBRepeat >= 1 && !fBOUNCE {
+		fREPEAT = true
+	}
+
+	///////////////////////////////////
+	////// EB handling - intial setup
+	///////////////////////////////////
+	// seed array with EB_LOW, and fill as appropriate
+	if g.opts.EBNum >= 1 && g.opts.EBStep > 0 {
+		for i := 0; i < g.opts.EBNum; i++ {
+			EBspeeds = append(EBspeeds, g.opts.EBLow+(i*g.opts.EBStep))
+		}
+	}
+
+	if g.opts.EBRepeat >= 1 && g.opts.EBStep > 0 {
+		for i := 0; i < g.opts.EBRepeat; i++ {
+			EBspeedsRepeat = append(EBspeedsRepeat, g.opts.EBLow+(i*g.opts.EBStep))
+		}
+	}
+
+	// EB fRAMP how many words per ramp section
+	if fRAMP && !fEFFRAMP {
+		sectionSize = num / g.opts.EBNum
+		lastSpeed = EBspeeds[0]
+
+		if !fREPEAT {
+			if g.opts.EBEff > 0 {
+				strOut += fmt.Sprintf("|w%d |e%d ", EBspeeds[0], g.opts.EBEff)
+			} else {
+				strOut += fmt.Sprintf("|w%d ", EBspeeds[0])
+			}
+			speedCount++
+		}
+	}
+
+	// EB EFFECTIVE_RAMP how many words per ramp section
+	if fEFFRAMP && !fRAMP {
+		sectionSize = num / g.opts.EBNum
+
+		lastSpeedEff = g.opts.EBEff
+		strOut += fmt.Sprintf("|w%d |e%d ", g.opts.EBLow, g.opts.EBEff)
+		counter = 0
+	}
+
+	////////////////////////////////////////
+	/// setup done, now process input words
+	/////////////////////////////////////////
+	// select words from array, then lower high water mark so all words get used
+
+	wcFlg := false
+	cnt := 0
+	dWord := ""
+
+	if g.opts.WordCount > 1 {
+		wcFlg = true
+	}
+
+	for index, wordOut := range words {
+
+		// if true we are linking words together to treat as a entity
+		if wcFlg {
+
+			if cnt < g.opts.WordCount {
+				dWord += wordOut
+				dWord += "_"
+				cnt++
+				continue
+			} else {
+				wordOut = strings.Trim(dWord, "_")
+				dWord = ""
+				cnt = 0
+			}
+
+			/*
+				if wcCnt == 1 {
+					wcCnt++
+					continue
+				} else if wcCnt <= g.opts.WordCount {
+					dWord += wordOut
+					dWord += "_"
+					wcCnt++
+
+					if wcCnt > g.opts.WordCount {
+						wcCnt = 1
+					}
+				}
+			*/
+		}
+
+		//////////////
+		// EB fBOUNCE
+		//////////////
+		if fBOUNCE {
+			speed := EBspeeds[0]
+
+			if len(EBspeeds) >= 1 {
+
+				for {
+					speed = EBspeeds[g.rng.Intn(len(EBspeeds))]
+
+					if speed != lastSpeed {
+						lastSpeed = speed
+						break
+					}
+				}
+			}
+
+			if g.opts.EBEff > 0 {
+				strOut += fmt.Sprintf("|w%d |e%d ", speed, speed-g.effDelta)
+			} else {
+				strOut += fmt.Sprintf("|w%d ", speed)
+			}
+		}
+
+		/////////////////
+		/// EB FAST_SLOW
+		/////////////////
+		if fSLOWFAST {
+			s := g.opts.EBLow
+
+			if ebinslow {
+				if ebslowcnt >= g.opts.EBSlow {
+					s = g.opts.EBLow + g.opts.EBStep // now fast
+					// slow words are done
+					ebfastcnt = 0
+
+					// keep eff same
+					strOut += fmt.Sprintf("%s|w%d ", alert(g.opts.EBSF), s)
+					ebinslow = false
+				}
+			} else {
+				if ebfastcnt >= g.opts.EBFast || firstSlowFast {
+					firstSlowFast = false
+					s := g.opts.EBLow // now slow
+
+					// fast words are done
+					ebslowcnt = 0
+
+					// set up slow section
+					if index == 0 {
+						if g.opts.EBEff > 0 {
+							strOut += fmt.Sprintf("|e%d |w%d ", g.opts.EBEff, s)
+						} else {
+							strOut += fmt.Sprintf("|w%d ", s)
+						}
+					} else {
+						strOut += fmt.Sprintf("%s|w%d ", alert(g.opts.EBFS), s)
+					}
+					ebinslow = true
+				}
+			}
+		}
+
+		// end raw word, and get back word to print
+		wordOut, charSlice = g.prepWord(wordOut, lastSpeed, index, charSlice)
+
+		///////////////////////////////////
+		// EB CHECK FOR SPEED MARKERS
+		///////////////////////////////////
+
+		if fRAMP {
+
+			if counter >= sectionSize && speedCount < len(EBspeeds) {
+				sf := ""
+				if g.opts.EBSF != "" {
+					sf = " " + alert(g.opts.EBSF)
+				}
+
+				if index+g.opts.EBNum <= num {
+					if g.opts.EBEff > 0 {
+						strOut += fmt.Sprintf("%s%s|e%d |w%d ", wordOut, sf, EBspeeds[speedCount]-g.effDelta, EBspeeds[speedCount])
+					} else {
+						strOut += fmt.Sprintf("%s%s|w%d ", wordOut, sf, EBspeeds[speedCount])
+					}
+					wordOut = ""
+					speedCount++
+				}
+
+				if speedCount < len(EBspeeds) {
+					lastSpeed = EBspeeds[speedCount]
+				}
+				counter = 1
+			} else {
+				counter++
+			}
+		}
+
+		////////////
+		// fEFFRAMP
+		///////////
+		if fEFFRAMP {
+			if counter == sectionSize {
+				// ck if eff is going to over take word speed
+				if lastSpeedEff+g.opts.EBStep <= g.opts.EBLow {
+					// cap the eff speed
+					lastSpeedEff += g.opts.EBStep
+				}
+				strOut += fmt.Sprintf("%s|e%d ", wordOut, lastSpeedEff)
+				counter = 1
+				strOut += wordOut
+				wordOut = ""
+			} else {
+				counter++
+			}
+
+		}
+
+		//////////////
+		/// fSLOWFAST
+		//////////////
+		if fSLOWFAST {
+			if ebinslow {
+				ebslowcnt++
+			} else {
+				ebfastcnt++
+			}
+		}
+
+		if wcFlg {
+			// get back to individual words
+			wordOut = strings.ReplaceAll(wordOut, "_", " ")
+		}
+
+		// this is the processed word to be used
+		strBuf += strOut + wordOut
+		strOut = ""
+	}
+
+	return g.printStrBuf(strBuf, w)
+}
+
+// alertMark goes before and after each alert in the text printStrBuf is
+// given, so the words heard can be told from the alerts, which may be
+// the same words
+const alertMark = '\x00'
+
+// the EB_SF or EB_FS alert a, marked
+func alert(a string) string {
+	if a == "" {
+		return ""
+	}
+
+	return string(alertMark) + a + string(alertMark)
+}
+
+// prints the bufStr adjusting the length per g.opts.Len
+func (g *Generator) printStrBuf(strBuf string, w io.Writer) error {
+	// done processing now output it
+	res := ""
+	index := 0
+	for _, r := range strBuf {
+
+		// not printed, only noted for CopyItems
+		if r == alertMark {
+			g.alerts = append(g.alerts, len(res))
+			continue
+		}
+
+		if index <= g.opts.Len {
+			res = res + string(r)
+			index++
+			continue
+		}
+
+		if index >= g.opts.Len {
+			if r != ' ' && r != '\n' {
+				res = res + string(r)
+				index++
+				continue
+			} else {
+				res = res + "\n"
+				index = 0
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, res)
+	return err
+} // end
+
+// simple random true or false
+// func flipFlop(s string) bool {
+func (g *Generator) flipFlop() bool {
+	if g.rng.Intn(2) == 1 {
+		return true
+	}
+	return false
+}
+
+/*
+** take in a raw word from input file and tack on: prefix, suffix
+** repeat if necessay,do mixedMode
+ */
+func (g *Generator) prepWord(wordOut string, lastSpeed int, index int, charSlice []rune) (string, []rune) {
+	strOut := ""
+	rand := 3
+
+	if g.opts.Random {
+		if g.opts.Suffix >= 1 || g.opts.Prefix >= 1 {
+			// 0 - neither ix, 1 prefix,2 do suffix, 3 both
+			rand = g.rng.Intn(4)
+		}
+	}
+
+	// end raw word, and get back word to print
+	// do we need prefix?
+	if g.opts.Prefix >= 1 && (rand == 3 || rand == 1) {
+		wordOut = g.ixStr("p") + wordOut
+	}
+
+	// do we need a suffix or just a space
+	if g.opts.Suffix >= 1 && (rand == 3 || rand == 2) {
+		wordOut += g.ixStr("s")
+	}
+
+	// text repeat!
+	if g.opts.Repeat > 0 {
+		// we need to repeat
+		wordOut += " "
+		temp := wordOut
+
+		for cnt := 1; cnt < g.opts.Repeat; cnt++ {
+			// wordOut is the word plus trailing space already
+			wordOut += temp
+		}
+	}
+
+	// EB_REPEAT
+	if g.opts.EBRepeat > 1 {
+		for i := 0; i < g.opts.EBRepeat; i++ {
+			// if we ALSO have fRAMP we must offset speed
+			spd := lastSpeed + (i * g.opts.EBStep)
+
+			if g.opts.EBEff > 0 {
+				strOut += fmt.Sprintf("|w%d |e%d %s", spd, spd-g.effDelta, wordOut)
+			} else {
+				strOut += fmt.Sprintf("|w%d %s", spd, wordOut)
+			}
+
+			if g.opts.EBRamp {
+				spd += lastSpeed
+			}
+		}
+	}
+
+	// why WDL
+	/*
+		if g.opts.EBRamp && g.opts.EBRepeat == 0 {
+			strOut = wordOut
+			wordOut = ""
+		}
+	*/
+
+	// mixedMode put out code Group
+	if g.opts.MixedMode > 1 && (g.opts.MMR == false || (g.opts.MMR == true && g.flipFlop())) {
+		cg := []rune{}
+
+		if index%g.opts.MixedMode == 0 {
+			if g.opts.EBSF != "" {
+				strOut += alert(g.opts.EBSF) + " "
+			}
+
+			cg, charSlice = g.makeSingleGroup(charSlice)
+			strOut += string(cg)
+			if g.opts.EBFS != "" {
+				strOut += alert(g.opts.EBFS) + " "
+			}
+		}
+	}
+
+	// this means NOTHING was done to the word
+	if strOut == "" {
+		strOut = wordOut
+	}
+
+	// use delimiter
+	strOut += g.delimiter()
+
+	return strOut, charSlice
+}
+
+// returns the delimiter string(s) to follow a word or code group, if any
+func (g *Generator) delimiter() string {
+	d := ""
+
+	if g.opts.DM > 0 && (g.opts.DR == false || (g.opts.DR == true && g.flipFlop())) {
+		for i := 1; i <= (1 + g.rng.Intn(g.opts.DM)); i++ {
+			d += g.delimiterSlice[g.rng.Intn(len(g.delimiterSlice))]
+		}
+		d += " "
+	}
+
+	return d
+}
+
+// the header line(s) to start the output, if any
+func (g *Generator) header() string {
+	h := g.opts.Header
+
+	if g.opts.SeedHeader {
+		if h != "" {
+			h += " "
+		}
+		h += fmt.Sprintf("seed=%d", g.seed)
+	}
+
+	if h == "" {
+		return ""
+	}
+
+	return h + "\n"
+}
+
+// WriteWAV writes text made by Generate as Morse audio, a WAV file. The
+// speed commands of the EB_ options in the text are honored, the header is
+// not keyed.
+func (g *Generator) WriteWAV(w io.Writer, text string) error {
+	return morse.WriteWAV(w, g.wavText(text), g.sound())
+}
+
+// the sound of the wav options
+func (g *Generator) sound() morse.Sound {
+	sound := morse.DefaultSound()
+	sound.WPM = g.opts.WavWPM
+	sound.Effective = g.opts.WavEff
+	sound.Freq = g.opts.WavFreq
+
+	return sound
+}
+
+// wavText is text without the header words, the words AnswerKey lists
+func (g *Generator) wavText(text string) string {
+	fields := []string{}
+
+	for _, tokens := range g.bodyLines(text) {
+		for _, t := range tokens {
+			fields = append(fields, t.Text)
+		}
+	}
+
+	return strings.Join(fields, " ")
+}
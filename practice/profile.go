@@ -0,0 +1,180 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"bufio"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/wa2nfn/cwpt/koch"
+)
+
+// english letter frequency, percent of letters in typical text
+var englishFreq = map[rune]float64{
+	'a': 8.2, 'b': 1.5, 'c': 2.8, 'd': 4.3, 'e': 12.7, 'f': 2.2, 'g': 2.0,
+	'h': 6.1, 'i': 7.0, 'j': 0.15, 'k': 0.77, 'l': 4.0, 'm': 2.4, 'n': 6.7,
+	'o': 7.5, 'p': 1.9, 'q': 0.095, 'r': 6.0, 's': 6.3, 't': 9.1, 'u': 2.8,
+	'v': 0.98, 'w': 2.4, 'x': 0.15, 'y': 2.0, 'z': 0.074,
+}
+
+// Profiles are the -profile choices, how often each cglist character is
+// used in a code group.
+var Profiles = []string{"uniform", "english", "corpus", "file"}
+
+// setupProfile makes the code group weights of the -profile
+func (g *Generator) setupProfile(errs *ValidationErrors) {
+	o := &g.opts
+	o.Profile = strings.ToLower(o.Profile)
+
+	if o.ProfileFile != "" && o.Profile != "file" {
+		errs.add("profileFile", o.ProfileFile, "requires profile=file")
+	}
+
+	switch o.Profile {
+	case "", "uniform":
+		return
+	case "english":
+		g.profile = make(map[rune]float64)
+		for _, r := range g.cglistRune {
+			w, ok := englishFreq[unicode.ToLower(r)]
+			if !ok {
+				// not a letter, as often as an average letter
+				w = 100.0 / 26
+			}
+			g.profile[unicode.ToLower(r)] = w
+		}
+	case "corpus":
+		if o.Input == "" {
+			errs.add("profile", o.Profile, "requires an in file to count the characters of")
+			return
+		}
+		g.profile = g.corpusProfile(errs)
+	case "file":
+		if o.ProfileFile == "" {
+			errs.add("profile", o.Profile, "requires a profileFile of char:weight lines")
+			return
+		}
+		g.profile = g.fileProfile(errs)
+	default:
+		errs.add("profile", o.Profile, "choices are (case insensitive): %s", strings.Join(Profiles, ", "))
+		return
+	}
+
+	if g.profile == nil {
+		return
+	}
+
+	if !o.CodeGroups && o.MixedMode == 0 {
+		errs.add("profile", o.Profile, "requires codeGroups or mixedMode, it only weights code group characters")
+		return
+	}
+
+	total := 0.0
+	for _, r := range g.cglistRune {
+		total += g.profileWeight(r)
+	}
+
+	if total <= 0 {
+		errs.add("profile", o.Profile, "every cglist character has a weight of 0")
+	}
+}
+
+// corpusProfile counts each cglist character in the input file, plus one so
+// a character the text doesn't have is still sent once in a while
+func (g *Generator) corpusProfile(errs *ValidationErrors) map[rune]float64 {
+	file, closeFiles, err := g.openInput()
+	if err != nil {
+		errs.addCause("in", g.opts.Input, err)
+		return nil
+	}
+	defer closeFiles()
+
+	p := make(map[rune]float64)
+	for _, r := range g.cglistRune {
+		p[unicode.ToLower(r)] = 1
+	}
+
+	scanner := koch.NewScanner(file)
+	for scanner.Scan() {
+		for _, r := range strings.ToLower(scanner.Text()) {
+			if _, ok := p[r]; ok {
+				p[r]++
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		errs.addCause("in", g.opts.Input, err)
+		return nil
+	}
+
+	return p
+}
+
+// fileProfile reads char:weight lines, %XX for an international character,
+// a character not in the file has a weight of 1
+func (g *Generator) fileProfile(errs *ValidationErrors) map[rune]float64 {
+	file, err := os.Open(g.opts.ProfileFile)
+	if err != nil {
+		errs.addCause("profileFile", g.opts.ProfileFile, err)
+		return nil
+	}
+	defer file.Close()
+
+	p := make(map[rune]float64)
+	for _, r := range g.cglistRune {
+		p[unicode.ToLower(r)] = 1
+	}
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// the character may be ":" itself
+		i := strings.LastIndex(line, ":")
+		if i < 1 {
+			errs.add("profileFile", g.opts.ProfileFile, "line <%d> <%s> must be char:weight, weight >= 0", lineNum, line)
+			continue
+		}
+
+		char := koch.ExpandPercent(strings.TrimSpace(line[:i]))
+		w, err := strconv.ParseFloat(strings.TrimSpace(line[i+1:]), 64)
+
+		if utf8.RuneCountInString(char) != 1 || err != nil || w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			errs.add("profileFile", g.opts.ProfileFile, "line <%d> <%s> must be char:weight, weight >= 0", lineNum, line)
+			continue
+		}
+
+		r, _ := utf8.DecodeRuneInString(char)
+		p[unicode.ToLower(r)] = w
+	}
+
+	if err := scanner.Err(); err != nil {
+		errs.addCause("profileFile", g.opts.ProfileFile, err)
+	}
+
+	return p
+}
+
+// profile weight of r, either case, 1 if none
+func (g *Generator) profileWeight(r rune) float64 {
+	if w, ok := g.profile[unicode.ToLower(r)]; ok {
+		return w
+	}
+
+	return 1
+}
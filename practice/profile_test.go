@@ -0,0 +1,100 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProfile(t *testing.T) {
+	opts := testOptions()
+	opts.CodeGroups = true
+	opts.Num = 20
+	opts.Profile = "english"
+
+	checkGolden(t, "profile_english", generate(t, opts))
+}
+
+// counts of each character of code groups made with profile
+func profileCounts(t *testing.T, set func(o *Options)) map[rune]int {
+	t.Helper()
+
+	opts := testOptions()
+	opts.CodeGroups = true
+	opts.Num = 2000
+	opts.Cglist = "a-e"
+	set(&opts)
+
+	counts := make(map[rune]int)
+	for _, r := range generate(t, opts) {
+		if r >= 'a' && r <= 'e' {
+			counts[r]++
+		}
+	}
+
+	return counts
+}
+
+func TestProfileWeights(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "profile.txt")
+	if err := os.WriteFile(file, []byte("# weights\na:0\nb : 10\n\nC:1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	corpus := filepath.Join(dir, "corpus.txt")
+	if err := os.WriteFile(corpus, []byte(strings.Repeat("deed ", 100)+"abc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// uniform is about 2000 a character
+	c := profileCounts(t, func(o *Options) {})
+	if c['a'] < 1500 || c['e'] < 1500 {
+		t.Errorf("uniform counts %v, want about even", c)
+	}
+
+	// e is the most frequent english letter
+	c = profileCounts(t, func(o *Options) { o.Profile = "English" })
+	if c['e'] < 3*c['b'] {
+		t.Errorf("english counts %v, want e far more than b", c)
+	}
+
+	// the file weights, d and e not listed are 1
+	c = profileCounts(t, func(o *Options) { o.Profile, o.ProfileFile = "file", file })
+	if c['a'] != 0 || c['b'] < 5*c['c'] || c['c'] < c['d']/2 {
+		t.Errorf("file counts %v, want no a, b 10 times c, c as d", c)
+	}
+
+	// the characters counted in the in file
+	c = profileCounts(t, func(o *Options) { o.Profile, o.Input = "corpus", corpus })
+	if c['d'] < 20*c['a'] || c['e'] < 20*c['a'] {
+		t.Errorf("corpus counts %v, want d and e far more than a", c)
+	}
+}
+
+func TestProfileFileErrors(t *testing.T) {
+	for _, line := range []string{"a", "ab:1", "a:-1", "a:NaN", "a:Inf", ":3", "a:x"} {
+		file := filepath.Join(t.TempDir(), "profile.txt")
+		if err := os.WriteFile(file, []byte("b:2\n"+line+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		opts := testOptions()
+		opts.CodeGroups = true
+		opts.Profile = "file"
+		opts.ProfileFile = file
+
+		_, err := New(opts)
+
+		var errs ValidationErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "profileFile" || !strings.Contains(errs[0].Rule, "line <2>") {
+			t.Errorf("line %q: New error %v, want a profileFile error for line 2", line, err)
+		}
+	}
+}
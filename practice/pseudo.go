@@ -0,0 +1,115 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import "strings"
+
+// characters of context for the pseudo word chain
+const pseudoOrder = 2
+
+// the characters seen after a context, in the order first seen
+type pseudoNext struct {
+	chars  []rune
+	counts []int
+}
+
+// end of a word in the chain
+const pseudoEnd = rune(0)
+
+// makePseudoWords learns which character follows each pair of characters in
+// words, then makes num words that follow the same odds but aren't in words.
+// The words already matched inlist (and so the lesson), so only those
+// characters can be used.
+func (g *Generator) makePseudoWords(words []string) []string {
+	chain := make(pseudoChain)
+	real := make(map[string]bool)
+
+	for _, w := range words {
+		real[w] = true
+
+		// a prosign isn't spelled
+		if strings.HasPrefix(w, "<") {
+			continue
+		}
+
+		runes := []rune(w)
+		context := make([]rune, pseudoOrder)
+		for _, r := range append(runes, pseudoEnd) {
+			chain.add(string(context), r)
+			context = append(context[1:], r)
+		}
+	}
+
+	pseudo := make([]string, 0, g.opts.Num)
+	seen := make(map[string]bool)
+
+	// some input can't make many new words, don't try forever
+	for tries := 0; len(pseudo) < g.opts.Num && tries < 100*g.opts.Num; tries++ {
+		w := g.pseudoWord(chain)
+
+		if w == "" || real[w] || g.opts.Unique && seen[w] {
+			continue
+		}
+
+		seen[w] = true
+		pseudo = append(pseudo, w)
+	}
+
+	return pseudo
+}
+
+// pseudoWord walks the chain, "" if the word is outside min to max
+func (g *Generator) pseudoWord(chain pseudoChain) string {
+	word := []rune{}
+	context := make([]rune, pseudoOrder)
+
+	for {
+		next := chain[string(context)]
+		if next == nil {
+			return ""
+		}
+
+		r := next.chars[g.weightedIndex(len(next.chars), func(i int) float64 { return float64(next.counts[i]) })]
+
+		if r == pseudoEnd {
+			break
+		}
+
+		word = append(word, r)
+		if len(word) > g.opts.Max {
+			return ""
+		}
+
+		context = append(context[1:], r)
+	}
+
+	if len(word) < g.opts.Min || !g.ditsOK(string(word)) {
+		return ""
+	}
+
+	return string(word)
+}
+
+// pseudoChain is each context and what follows it
+type pseudoChain map[string]*pseudoNext
+
+// count r after context
+func (c pseudoChain) add(context string, r rune) {
+	next := c[context]
+	if next == nil {
+		next = &pseudoNext{}
+		c[context] = next
+	}
+
+	for i, have := range next.chars {
+		if have == r {
+			next.counts[i]++
+			return
+		}
+	}
+
+	next.chars = append(next.chars, r)
+	next.counts = append(next.counts, 1)
+}
@@ -0,0 +1,73 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPseudo(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Pseudo = true
+	opts.Num = 20
+
+	checkGolden(t, "pseudo", generate(t, opts))
+}
+
+func TestPseudoWords(t *testing.T) {
+	text, err := os.ReadFile(testText)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the words of the text, and each run of three characters in them
+	// with the spaces around a word
+	real := make(map[string]bool)
+	runs := make(map[string]bool)
+	for _, w := range strings.Fields(strings.ToLower(string(text))) {
+		w = strings.Trim(w, ".,!?")
+		real[w] = true
+
+		r := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			runs[string(r[i:i+3])] = true
+		}
+	}
+
+	opts := testOptions()
+	opts.Input = testText
+	opts.Pseudo = true
+	opts.Unique = true
+	opts.Num = 100
+	opts.Min = 3
+	opts.Max = 6
+
+	seen := make(map[string]bool)
+	for _, w := range strings.Fields(strings.ToLower(generate(t, opts))) {
+		if real[w] || seen[w] {
+			t.Errorf("%s is a real word, or sent twice", w)
+		}
+		seen[w] = true
+
+		if n := len([]rune(w)); n < opts.Min || n > opts.Max {
+			t.Errorf("%s is not %d to %d characters", w, opts.Min, opts.Max)
+		}
+
+		// the chain only makes runs it has read
+		r := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			if !runs[string(r[i:i+3])] {
+				t.Errorf("%s has %q, not in the text", w, string(r[i:i+3]))
+			}
+		}
+	}
+
+	if len(seen) < 20 {
+		t.Errorf("only %d pseudo words", len(seen))
+	}
+}
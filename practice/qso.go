@@ -0,0 +1,135 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ragchew QSO templates, one is picked for each turn. In a template {me} is
+// the station sending, {you} the other station, the rest are filled at random.
+var qsoTurns = [][]string{
+	// the CQ
+	{
+		"cq cq cq de {me} {me} {me} k",
+		"cq cq de {me} {me} k",
+		"cq cq cq cq de {me} {me} {me} <ar> k",
+	},
+	// the answer
+	{
+		"{you} de {me} {me} k",
+		"{you} de {me} {me} {me} <ar> kn",
+		"{you} {you} de {me} {me} k",
+	},
+	// first exchange
+	{
+		"{you} de {me} {greet} es tnx fer call <bt> ur rst {rst} {rst} <bt> name {name} {name} <bt> qth {qth} {qth} <bt> hw cpy? <ar> {you} de {me} kn",
+		"{you} de {me} r r {greet} om <bt> ur {rst} {rst} in {qth} <bt> op {name} {name} <bt> so hw? <ar> {you} de {me} kn",
+		"{you} de {me} tu {greet} <bt> rst {rst} {rst} <bt> qth is {qth} {qth} <bt> name is {name} {name} <ar> {you} de {me} k",
+	},
+	// answer exchange
+	{
+		"{you} de {me} r r fb {yname} tnx fer rpt <bt> ur rst {rst} {rst} <bt> name {name} {name} <bt> qth {qth} {qth} <bt> hw? <ar> {you} de {me} kn",
+		"{you} de {me} solid cpy {yname} <bt> ur {rst} {rst} <bt> op here {name} {name} es qth {qth} {qth} <ar> {you} de {me} kn",
+		"{you} de {me} r tnx {yname} gud to meet u <bt> rst {rst} {rst} <bt> name {name} {name} <bt> qth nr {qth} <ar> {you} de {me} kn",
+	},
+	// station and weather
+	{
+		"{you} de {me} r r {yname} <bt> rig hr {rig} at {watts} es ant {ant} <bt> wx {wx} temp {temp} <ar> {you} de {me} kn",
+		"{you} de {me} fb {yname} <bt> running {watts} to {ant} <bt> rig is {rig} <bt> wx hr {wx} es {temp} <ar> {you} de {me} kn",
+		"{you} de {me} r <bt> rig {rig} pwr {watts} ant {ant} <bt> wx {wx} abt {temp} <ar> {you} de {me} kn",
+	},
+	// reply station and weather
+	{
+		"{you} de {me} r r tnx fer info {yname} <bt> hr rig {rig} es {watts} to {ant} <bt> wx {wx} temp {temp} <ar> {you} de {me} kn",
+		"{you} de {me} fb {yname} <bt> {rig} at {watts} hr es ant {ant} <bt> wx {wx} {temp} <ar> {you} de {me} kn",
+	},
+	// sign off
+	{
+		"{you} de {me} r r tnx fer fb qso {yname} <bt> hpe cuagn 73 <ar> {you} de {me} <sk>",
+		"{you} de {me} tnx {yname} es gl <bt> 73 es cul <ar> {you} de {me} <sk>",
+		"{you} de {me} ok {yname} must qrt <bt> tnx qso 73 <sk> {you} de {me}",
+	},
+	// last 73
+	{
+		"{you} de {me} r tnx {yname} 73 <sk> ee",
+		"73 {yname} gl <sk> {you} de {me} ee",
+		"{you} de {me} fb 73 es gud dx <sk> tu ee",
+	},
+}
+
+var (
+	qsoGreet = []string{"gm", "ga", "ge", "gd"}
+	qsoRST   = []string{"599", "579", "589", "559", "449", "569", "5nn", "339"}
+	qsoNames = []string{
+		"bob", "jim", "tom", "bill", "mike", "dave", "joe", "sam", "ann", "sue",
+		"kim", "pat", "ed", "al", "ray", "don", "ken", "jan", "liz", "rick",
+		"hans", "ole", "ivan", "yuki", "jean", "marco", "pete", "walt", "gus", "mary",
+	}
+	qsoQTH = []string{
+		"boston ma", "denver co", "austin tx", "fresno ca", "tampa fl", "salem or",
+		"dayton oh", "reno nv", "albany ny", "omaha ne", "tucson az", "macon ga",
+		"erie pa", "butte mt", "bangor me", "provo ut", "london", "paris", "berlin",
+		"toronto", "sydney", "tokyo", "madrid", "oslo",
+	}
+	qsoRigs  = []string{"ic7300", "k3", "kx2", "kx3", "ft991a", "ts590", "ft dx10", "k4", "qcx", "ic705", "ts2000", "homebrew"}
+	qsoWatts = []string{"5w", "10w", "50w", "100w", "200w", "500w", "1kw"}
+	qsoAnts  = []string{"dipole", "vertical", "yagi", "efhw", "g5rv", "loop", "inv vee", "hex beam", "wire", "windom"}
+	qsoWx    = []string{"sunny", "cldy", "rain", "snow", "fog", "windy", "clear", "hot", "cold", "hazy"}
+)
+
+// makeQSOs returns num QSOs as words, to be output like words
+func (g *Generator) makeQSOs() []string {
+	words := []string{}
+
+	for i := 0; i < g.opts.Num; i++ {
+		words = append(words, strings.Fields(g.qso())...)
+	}
+
+	return words
+}
+
+// qso makes one QSO, the turns alternate between the two stations
+func (g *Generator) qso() string {
+	calls := [2]string{g.callsign(), g.callsign()}
+	names := [2]string{g.pick(qsoNames), g.pick(qsoNames)}
+
+	turns := []string{}
+
+	for i, choices := range qsoTurns {
+		me := i % 2
+		you := 1 - me
+
+		r := strings.NewReplacer(
+			"{me}", calls[me],
+			"{you}", calls[you],
+			"{name}", names[me],
+			"{yname}", names[you],
+			"{greet}", g.pick(qsoGreet),
+			"{rst}", g.pick(qsoRST),
+			"{qth}", g.pick(qsoQTH),
+			"{rig}", g.pick(qsoRigs),
+			"{watts}", g.pick(qsoWatts),
+			"{ant}", g.pick(qsoAnts),
+			"{wx}", g.pick(qsoWx),
+			"{temp}", fmt.Sprintf("%df", 10+g.rng.Intn(85)),
+		)
+
+		turns = append(turns, r.Replace(g.pick(choices)))
+	}
+
+	text := strings.Join(turns, " ")
+	if g.opts.Caps {
+		return strings.ToUpper(text)
+	}
+
+	return text
+}
+
+// pick one of list
+func (g *Generator) pick(list []string) string {
+	return list[g.rng.Intn(len(list))]
+}
@@ -0,0 +1,55 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQSO(t *testing.T) {
+	opts := testOptions()
+	opts.QSO = true
+	opts.Num = 1
+
+	checkGolden(t, "qso", generate(t, opts))
+}
+
+func TestQSOTurns(t *testing.T) {
+	opts := testOptions()
+	opts.QSO = true
+	opts.Num = 20
+
+	text := generate(t, opts)
+	if strings.ContainsAny(text, "{}") {
+		t.Fatalf("a template field is left in:\n%s", text)
+	}
+
+	// each QSO is a CQ, answered by the call after de, ended by ee
+	words := strings.Fields(text)
+	qsos := 0
+	for i, w := range words {
+		if w != "cq" || i > 0 && words[i-1] != "ee" {
+			continue
+		}
+		qsos++
+
+		// the CQ ends with k, the answer starts with the caller's call
+		de, k := i, i
+		for words[de] != "de" {
+			de++
+		}
+		for words[k] != "k" {
+			k++
+		}
+		if caller := words[de+1]; words[k+1] != caller {
+			t.Errorf("QSO %d: cq by %s answered as %s", qsos, caller, words[k+1])
+		}
+	}
+
+	if qsos != opts.Num || words[len(words)-1] != "ee" {
+		t.Errorf("%d QSOs ending %q, want %d ending ee", qsos, words[len(words)-1], opts.Num)
+	}
+}
@@ -0,0 +1,136 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// message text without an -in file, ARL numbered radiograms are spelled out
+var rgPhrases = []string{
+	"arl one", "arl four", "arl six", "arl forty six", "arl fifty",
+	"greetings by amateur radio", "happy birthday", "merry christmas", "happy new year",
+	"all well here", "hope you are well", "love to all", "will write soon", "miss you",
+	"please call home", "arriving sunday", "x", "x", "best wishes", "congratulations",
+	"net meets tuesday 2000 local", "thanks for your help", "see you at the hamfest",
+}
+
+var (
+	rgPrecedence = []string{"r", "r", "r", "r", "w", "p", "emergency"}
+	rgHX         = []string{"", "", "", "hxg", "hxc", "hxd", "hxe", "hxb24", "hxb48"}
+	rgMonths     = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	rgLastNames  = []string{"smith", "jones", "brown", "miller", "davis", "wilson", "moore", "taylor", "clark", "lewis", "walker", "young"}
+	rgStreets    = []string{"main st", "oak ave", "elm st", "park rd", "maple dr", "river rd", "hill st", "lake ave", "1st st", "pine ln"}
+	rgPlaces     = []string{
+		"newington ct", "boston ma", "denver co", "austin tx", "fresno ca", "tampa fl", "salem or",
+		"dayton oh", "reno nv", "albany ny", "omaha ne", "tucson az", "macon ga", "erie pa",
+	}
+)
+
+// makeRadiograms returns num NTS radiograms as words. Text words come from
+// the input file, after the usual filtering, or else from rgPhrases.
+func (g *Generator) makeRadiograms() ([]string, error) {
+	var pool []string
+
+	if g.opts.Input != "" {
+		// read enough words for every message, the file might repeat
+		num := g.opts.Num
+		g.opts.Num = num * g.rgMaxWords()
+
+		var err error
+		pool, err = g.readFileMode()
+		g.opts.Num = num
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	words := []string{}
+	number := g.rng.Intn(200)
+
+	for i := 0; i < g.opts.Num; i++ {
+		number++
+
+		rg := g.radiogram(number, &pool)
+		if g.opts.Caps {
+			rg = strings.ToUpper(rg)
+		}
+
+		words = append(words, strings.Fields(rg)...)
+	}
+
+	return words, nil
+}
+
+// the most text words a message can have
+func (g *Generator) rgMaxWords() int {
+	if g.opts.RGWords > 0 {
+		return g.opts.RGWords
+	}
+
+	return 25
+}
+
+// radiogram makes one message, text words are taken from the front of pool
+func (g *Generator) radiogram(number int, pool *[]string) string {
+	n := g.opts.RGWords
+	if n == 0 {
+		n = 5 + g.rng.Intn(21)
+	}
+
+	text := []string{}
+	arl := false
+
+	if len(*pool) > 0 {
+		if n > len(*pool) {
+			n = len(*pool)
+		}
+		text = append(text, (*pool)[:n]...)
+		*pool = (*pool)[n:]
+	} else {
+		// whole phrases that fit, "x" always does, one ARL at most
+		for len(text) < n {
+			p := strings.Fields(g.pick(rgPhrases))
+			if len(p) > n-len(text) || arl && p[0] == "arl" || p[0] == "x" && len(text) == 0 && n > 1 {
+				continue
+			}
+
+			arl = arl || p[0] == "arl"
+			text = append(text, p...)
+		}
+	}
+
+	// the check is the number of words in the text
+	check := fmt.Sprintf("%d", len(text))
+	if arl {
+		check = "arl " + check
+	}
+
+	preamble := []string{fmt.Sprintf("nr %d", number), g.pick(rgPrecedence)}
+	if hx := g.pick(rgHX); hx != "" {
+		preamble = append(preamble, hx)
+	}
+	preamble = append(preamble,
+		g.makeCall(false),
+		check,
+		g.pick(rgPlaces),
+		fmt.Sprintf("%02d%02dz", g.rng.Intn(24), g.rng.Intn(60)),
+		fmt.Sprintf("%s %d", g.pick(rgMonths), 1+g.rng.Intn(28)),
+	)
+
+	address := fmt.Sprintf("%s %s %d %s %s %05d %03d %03d %04d",
+		g.pick(qsoNames), g.pick(rgLastNames), 1+g.rng.Intn(9999), g.pick(rgStreets),
+		g.pick(rgPlaces), g.rng.Intn(100000), 200+g.rng.Intn(800), 200+g.rng.Intn(800), g.rng.Intn(10000))
+
+	signature := g.pick(qsoNames)
+	if g.rng.Intn(2) == 0 {
+		signature += " " + g.makeCall(false)
+	}
+
+	return fmt.Sprintf("%s %s <bt> %s <bt> %s <ar>",
+		strings.Join(preamble, " "), address, strings.Join(text, " "), signature)
+}
@@ -0,0 +1,69 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// the check of a message, the first number after "nr N", and if it has "arl"
+func rgCheck(preamble []string) (int, bool) {
+	for i := 2; i < len(preamble); i++ {
+		if n, err := strconv.Atoi(preamble[i]); err == nil {
+			return n, preamble[i-1] == "arl"
+		}
+	}
+
+	return -1, false
+}
+
+func TestRadiogramCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		rgWords int
+		pool    []string
+	}{
+		{"phrases, random length", 0, nil},
+		{"phrases, 1 word", 1, nil},
+		{"phrases, 12 words", 12, nil},
+		{"in file words", 8, strings.Fields("one two three four five six seven eight nine ten")},
+		{"in file runs short", 25, strings.Fields("one two three")},
+	}
+
+	for _, tt := range tests {
+		opts := DefaultOptions()
+		opts.Radiogram = true
+		opts.RGWords = tt.rgWords
+		opts.Seed = 1
+
+		g, err := New(opts)
+		if err != nil {
+			t.Fatalf("%s: New: %v", tt.name, err)
+		}
+
+		for number := 1; number <= 50; number++ {
+			pool := tt.pool
+			rg := g.radiogram(number, &pool)
+
+			parts := strings.Split(rg, " <bt> ")
+			if len(parts) != 3 {
+				t.Fatalf("%s: %q has %d parts, want preamble and address, text, signature", tt.name, rg, len(parts))
+			}
+
+			text := strings.Fields(parts[1])
+			check, arl := rgCheck(strings.Fields(parts[0]))
+
+			if check != len(text) {
+				t.Errorf("%s: %q check %d, the text has %d words", tt.name, rg, check, len(text))
+			}
+
+			if arl != strings.Contains(" "+parts[1]+" ", " arl ") {
+				t.Errorf("%s: %q arl check %v, want it only with an ARL text", tt.name, rg, arl)
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Ranks are the -rank choices, how words are chosen by their frequency.
+var Ranks = []string{"top", "band", "sample"}
+
+// setupRank checks the word frequency options
+func (g *Generator) setupRank(errs *ValidationErrors) {
+	o := &g.opts
+	o.Rank = strings.ToLower(o.Rank)
+
+	if o.Rank == "" {
+		if o.FreqList != "" {
+			errs.add("freqList", o.FreqList, "requires the rank option")
+		}
+		return
+	}
+
+	switch o.Rank {
+	case "top", "sample":
+	case "band":
+		if o.RankFrom < 1 || o.RankTo < o.RankFrom {
+			errs.add("rankFrom", o.RankFrom, "with rank=band, rankFrom must be >= 1 and <= rankTo <%d>", o.RankTo)
+		}
+	default:
+		errs.add("rank", o.Rank, "choices are (case insensitive): %s", strings.Join(Ranks, ", "))
+	}
+
+	if o.NR || o.CodeGroups || o.generated() || o.Pseudo || o.Sentences || o.NGram > 0 {
+		errs.add("rank", o.Rank, "chooses words from the in file, mutually exclusive with NR and the other modes")
+	}
+}
+
+// rankWords keeps the words of the -rank choice in g.wordOrder, most
+// frequent first. For "sample" it returns num words drawn by frequency.
+func (g *Generator) rankWords() ([]string, error) {
+	freq := g.wordFreq
+
+	if g.opts.FreqList != "" {
+		list, err := g.loadFreqList()
+		if err != nil {
+			return nil, err
+		}
+
+		// a word not in the list has no rank
+		freq = make(map[string]float64)
+		kept := []string{}
+		for _, w := range g.wordOrder {
+			if f, ok := list[g.freqKey(w)]; ok {
+				freq[w] = f
+				kept = append(kept, w)
+			}
+		}
+		g.wordOrder = kept
+	}
+
+	ranked := g.wordOrder
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return freq[ranked[i]] > freq[ranked[j]]
+	})
+
+	switch g.opts.Rank {
+	case "top":
+		if len(ranked) > g.opts.Num {
+			ranked = ranked[:g.opts.Num]
+		}
+	case "band":
+		from, to := g.opts.RankFrom-1, g.opts.RankTo
+		if to > len(ranked) {
+			to = len(ranked)
+		}
+		if from > to {
+			from = to
+		}
+		ranked = ranked[from:to]
+	case "sample":
+		return g.sampleByFreq(ranked, freq), nil
+	}
+
+	g.wordOrder = ranked
+	return nil, nil
+}
+
+// sampleByFreq draws num words, each as likely as it is frequent. With
+// -unique a word is drawn once at most.
+func (g *Generator) sampleByFreq(words []string, freq map[string]float64) []string {
+	// the frequencies added up, a draw is found by binary search
+	cum := make([]float64, len(words))
+	total := 0.0
+	for i, w := range words {
+		total += freq[w]
+		cum[i] = total
+	}
+
+	if len(words) == 0 || total <= 0 {
+		return nil
+	}
+
+	if g.opts.Unique {
+		g.weightedShuffle(words, func(w string) float64 { return freq[w] })
+
+		if len(words) > g.opts.Num {
+			words = words[:g.opts.Num]
+		}
+		return words
+	}
+
+	sample := make([]string, 0, g.opts.Num)
+	for len(sample) < g.opts.Num {
+		pick := g.rng.Float64() * total
+		i := sort.Search(len(cum), func(i int) bool { return cum[i] > pick })
+		if i == len(cum) {
+			i--
+		}
+		sample = append(sample, words[i])
+	}
+
+	return sample
+}
+
+// the frequency list key of an input word
+func (g *Generator) freqKey(w string) string {
+	w = strings.ToLower(w)
+	if g.opts.Reverse {
+		w = reverse(w)
+	}
+
+	return w
+}
+
+// loadFreqList reads "word count" lines, or just "word" lines most frequent
+// first, then a word's frequency is 1/rank like real text
+func (g *Generator) loadFreqList() (map[string]float64, error) {
+	file, err := os.Open(g.opts.FreqList)
+	if err != nil {
+		return nil, fmt.Errorf("%w File name <%s>", err, g.opts.FreqList)
+	}
+	defer file.Close()
+
+	list := make(map[string]float64)
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	rank := 0
+
+	for scanner.Scan() {
+		lineNum++
+		f := strings.Fields(scanner.Text())
+
+		if len(f) == 0 || strings.HasPrefix(f[0], "#") {
+			continue
+		}
+
+		w := strings.ToLower(f[0])
+		if _, ok := list[w]; ok {
+			continue
+		}
+		rank++
+
+		switch len(f) {
+		case 1:
+			list[w] = 1 / float64(rank)
+		case 2:
+			count, err := strconv.ParseFloat(f[1], 64)
+			if err != nil || count < 0 || math.IsNaN(count) || math.IsInf(count, 0) {
+				return nil, fmt.Errorf("line <%d> of file <%s>: want \"word\" or \"word count\"", lineNum, g.opts.FreqList)
+			}
+			list[w] = count
+		default:
+			return nil, fmt.Errorf("line <%d> of file <%s>: want \"word\" or \"word count\"", lineNum, g.opts.FreqList)
+		}
+	}
+
+	return list, scanner.Err()
+}
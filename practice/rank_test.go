@@ -0,0 +1,123 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// rankFile is a temp file of text
+func rankFile(t *testing.T, name string, text string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+// wordCounts counts each word of text
+func wordCounts(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range strings.Fields(text) {
+		counts[w]++
+	}
+
+	return counts
+}
+
+func TestRank(t *testing.T) {
+	in := rankFile(t, "in.txt", strings.Repeat("the ", 6)+strings.Repeat("and ", 3)+"you you dog zero")
+	list := rankFile(t, "freq.txt", "# most frequent first\nzero\ndog\nthe\n")
+	counts := rankFile(t, "counts.txt", "the 10\nand 0\nyou 30\n")
+
+	tests := []struct {
+		name string
+		set  func(o *Options)
+		want string // the words sent, sorted
+	}{
+		{"top", func(o *Options) { o.Rank, o.Num = "top", 2 }, "and the"},
+		{"band", func(o *Options) { o.Rank, o.RankFrom, o.RankTo, o.Num = "BAND", 2, 3, 2 }, "and you"},
+		{"band past the end", func(o *Options) { o.Rank, o.RankFrom, o.RankTo, o.Num = "band", 4, 9, 2 }, "dog zero"},
+		{"word list", func(o *Options) { o.Rank, o.FreqList, o.Num = "top", list, 2 }, "dog zero"},
+		{"count list", func(o *Options) { o.Rank, o.FreqList, o.Num = "top", counts, 2 }, "the you"},
+	}
+
+	for _, tt := range tests {
+		opts := testOptions()
+		opts.Input = in
+		tt.set(&opts)
+
+		words := strings.Fields(generate(t, opts))
+		sort.Strings(words)
+		if got := strings.Join(words, " "); got != tt.want {
+			t.Errorf("%s: words %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRankSample(t *testing.T) {
+	opts := testOptions()
+	opts.Input = rankFile(t, "in.txt", strings.Repeat("the ", 6)+strings.Repeat("and ", 3)+"you")
+	opts.Rank = "sample"
+	opts.Num = 10000
+
+	// drawn as often as each is in the text, 6:3:1
+	c := wordCounts(generate(t, opts))
+	if c["the"] < 5700 || c["the"] > 6300 || c["and"] < 2700 || c["and"] > 3300 || c["you"] < 800 || c["you"] > 1200 {
+		t.Errorf("sample counts %v, want about 6000, 3000 and 1000", c)
+	}
+
+	// a count of 0 is never drawn, unique draws each once
+	opts.FreqList = rankFile(t, "counts.txt", "the 1\nand 0\nyou 1\n")
+	if c := wordCounts(generate(t, opts)); c["and"] != 0 {
+		t.Errorf("sample counts %v, want no and", c)
+	}
+
+	opts.FreqList = ""
+	opts.Unique = true
+	if c := wordCounts(generate(t, opts)); len(c) != 3 || c["the"] != 1 || c["you"] != 1 {
+		t.Errorf("unique sample counts %v, want each once", c)
+	}
+}
+
+func TestRankFreqListErrors(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Rank = "top"
+
+	for _, line := range []string{"the 1 2", "the x", "the -1", "the NaN", "the Inf"} {
+		opts.FreqList = rankFile(t, "freq.txt", "and 5\n"+line+"\n")
+
+		g, err := New(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := g.Generate(); err == nil || !strings.Contains(err.Error(), "line <2>") {
+			t.Errorf("line %q: Generate error %v, want one for line 2", line, err)
+		}
+	}
+
+	// a list that can't be read keeps the cause
+	opts.FreqList = filepath.Join(t.TempDir(), "none.txt")
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pathErr *fs.PathError
+	if _, err := g.Generate(); !errors.As(err, &pathErr) || !strings.Contains(err.Error(), "File name <"+opts.FreqList+">") {
+		t.Errorf("Generate error %v, want a *fs.PathError with the file name", err)
+	}
+}
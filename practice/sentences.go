@@ -0,0 +1,149 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/wa2nfn/cwpt/koch"
+)
+
+// makeSentences returns num sentences of the input file, each one is a word
+// so repeat and the delimiter act on the whole sentence. The order is random,
+// or as read with -NR.
+func (g *Generator) makeSentences() ([]string, error) {
+	file, closeFiles, err := g.openInput()
+	if err != nil {
+		return nil, err
+	}
+	defer closeFiles()
+
+	allowed := g.sendable()
+
+	sentences := []string{}
+	discarded := false
+	skip := g.opts.Skip
+
+	keep := func(text string) {
+		s, ok := g.cleanSentence(text, allowed)
+		if !ok {
+			discarded = discarded || strings.TrimSpace(text) != ""
+			return
+		}
+
+		if skip > 0 {
+			skip--
+			return
+		}
+
+		sentences = append(sentences, s)
+	}
+
+	scanner := koch.NewScanner(file)
+	var sb strings.Builder
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// a blank line ends a paragraph, so a heading isn't run into the text
+		if line == "" {
+			keep(sb.String())
+			sb.Reset()
+			continue
+		}
+
+		runes := []rune(line)
+		for i, r := range runes {
+			sb.WriteRune(r)
+
+			if strings.ContainsRune(".!?", r) && endsSentence(runes[i+1:]) {
+				keep(sb.String())
+				sb.Reset()
+			}
+		}
+		sb.WriteRune(' ')
+	}
+	keep(sb.String())
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(sentences) == 0 {
+		return nil, g.nothingToOutput(discarded, skip > 0)
+	}
+
+	if g.opts.NR {
+		// in order, from the start again if short
+		for i := 0; len(sentences) < g.opts.Num; i++ {
+			sentences = append(sentences, sentences[i])
+		}
+		return sentences[:g.opts.Num], nil
+	}
+
+	for _, s := range sentences {
+		g.addWord(s)
+	}
+
+	g.shuffle(g.wordOrder)
+	if len(g.wordOrder) > g.opts.Num {
+		g.wordOrder = g.wordOrder[:g.opts.Num]
+	}
+
+	return g.fillArray(), nil
+}
+
+// endsSentence is true if what follows the . ! or ? is the end of the line,
+// or a space, maybe after closing quotes
+func endsSentence(rest []rune) bool {
+	for _, r := range rest {
+		switch {
+		case r == '"' || r == '\'' || r == ')' || r == '”' || r == '’':
+			continue
+		case unicode.IsSpace(r):
+			return true
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// cleanSentence drops quotes and makes other punctuation that can't be sent
+// a space, false if a letter or digit can't be sent
+func (g *Generator) cleanSentence(text string, allowed func(rune) bool) (string, bool) {
+	var sb strings.Builder
+
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			sb.WriteRune(' ')
+		case allowed(r):
+			sb.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return "", false
+		case strings.ContainsRune(`'"‘’“”`, r):
+			// quotes and apostrophes never split a word, don't becomes dont
+		default:
+			// unsendable punctuation, a dash between words is a space
+			sb.WriteRune(' ')
+		}
+	}
+
+	s := strings.Join(strings.Fields(sb.String()), " ")
+
+	// nothing but punctuation isn't a sentence
+	if strings.IndexFunc(s, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+		return "", false
+	}
+
+	if g.opts.Caps {
+		return strings.ToUpper(s), true
+	}
+
+	return strings.ToLower(s), true
+}
@@ -0,0 +1,87 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSentences(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Sentences = true
+	opts.Num = 4
+
+	checkGolden(t, "sentences", generate(t, opts))
+}
+
+// sentencesOf returns the sentences made of text with opts
+func sentencesOf(t *testing.T, text string, set func(o *Options)) ([]string, error) {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "in.txt")
+	if err := os.WriteFile(file, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := testOptions()
+	opts.Input = file
+	opts.Sentences = true
+	opts.NR = true
+	set(&opts)
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g.makeSentences()
+}
+
+func TestSentencesSplit(t *testing.T) {
+	text := "Hello world. How are you?  I'm fine, \"thanks\" -- see\n3.5 mhz! A line\nthat wraps. Über café.\n"
+
+	tests := []struct {
+		name string
+		set  func(o *Options)
+		want []string
+	}{
+		{"as read", func(o *Options) { o.Num = 5 }, []string{
+			"hello world.", "how are you?", "im fine, thanks see 3.5 mhz", "a line that wraps.", "über café.",
+		}},
+		{"from the start again", func(o *Options) { o.Num = 7 }, []string{
+			"hello world.", "how are you?", "im fine, thanks see 3.5 mhz", "a line that wraps.", "über café.",
+			"hello world.", "how are you?",
+		}},
+		{"skip and caps", func(o *Options) { o.Num, o.Skip, o.Caps = 2, 3, true }, []string{
+			"A LINE THAT WRAPS.", "ÜBER CAFÉ.",
+		}},
+		{"unsendable letter", func(o *Options) { o.Num, o.Inlist = 4, "a-z" }, []string{
+			"hello world.", "how are you?", "im fine, thanks see 3.5 mhz", "a line that wraps.",
+		}},
+		{"no digits", func(o *Options) { o.Num, o.Cglist = 3, ".?" }, []string{
+			"hello world.", "how are you?", "a line that wraps.",
+		}},
+	}
+
+	for _, tt := range tests {
+		got, err := sentencesOf(t, text, tt.set)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: makeSentences = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestSentencesNone(t *testing.T) {
+	_, err := sentencesOf(t, "Zzz zzz. Qqq!", func(o *Options) { o.Lesson = 2 })
+	if !errors.Is(err, ErrNothingToOutput) || !strings.Contains(err.Error(), "DID have some text") {
+		t.Errorf("makeSentences error %v, want ErrNothingToOutput of text discarded", err)
+	}
+}
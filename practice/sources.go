@@ -0,0 +1,326 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Source is one of the comma separated items of the in option, the files it
+// names and its weight, 0 when none is given.
+type Source struct {
+	Name   string
+	Files  []string
+	Weight int
+}
+
+// ParseSources splits the in option into its sources. A source is a file, a
+// glob pattern, a directory, read recursively, or "-" for Options.Stdin, optionally
+// followed by ":weight" as in "novel.txt:70,-:30". Give every source a weight or none.
+// A file whose name has a comma or ends in ":digits" is still found by that name.
+func ParseSources(in string) ([]Source, error) {
+	sources := []Source{}
+	weighted := 0
+
+	items := strings.Split(in, ",")
+	for len(items) > 0 {
+		item, n := fileItem(items)
+		items = items[n:]
+
+		src := Source{Name: strings.TrimSpace(item)}
+
+		// a weight is all digits after the last colon, so C:\text is a name
+		if name, w, ok := splitWeight(src.Name); ok && !fileExists(src.Name) {
+			if w < 1 {
+				return nil, fmt.Errorf("source <%s> weight must be >= 1", src.Name)
+			}
+			src.Name = name
+			src.Weight = w
+			weighted++
+		}
+
+		if src.Name == "" {
+			return nil, fmt.Errorf("empty source in <%s>", in)
+		}
+
+		if src.Name == "-" {
+			src.Files = []string{"-"}
+		} else {
+			files, err := expandSource(src.Name)
+			if err != nil {
+				return nil, err
+			}
+			src.Files = files
+		}
+
+		sources = append(sources, src)
+	}
+
+	if weighted > 0 && weighted < len(sources) {
+		return nil, fmt.Errorf("give a weight to every source or none")
+	}
+
+	return sources, nil
+}
+
+// fileItem returns the first source of the split in option and how many
+// items it takes, more than one when joined again by commas they name a
+// file, with or without a weight.
+func fileItem(items []string) (string, int) {
+	for n := len(items); n > 1; n-- {
+		item := strings.Join(items[:n], ",")
+		name, _, ok := splitWeight(strings.TrimSpace(item))
+
+		if fileExists(strings.TrimSpace(item)) || ok && fileExists(name) {
+			return item, n
+		}
+	}
+
+	return items[0], 1
+}
+
+// splitWeight splits "name:weight", ok is false when there is no weight
+func splitWeight(item string) (string, int, bool) {
+	i := strings.LastIndex(item, ":")
+	if i < 0 {
+		return item, 0, false
+	}
+
+	w, err := strconv.Atoi(item[i+1:])
+	if err != nil {
+		return item, 0, false
+	}
+
+	return item[:i], w, true
+}
+
+// fileExists is true when name is a file or directory
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+// expandSource returns the files of a name, sorted so the seed repeats a
+// session. A file that can't be read is left for the open to report.
+func expandSource(name string) ([]string, error) {
+	names := []string{name}
+
+	if strings.ContainsAny(name, "*?[") {
+		matches, err := filepath.Glob(name)
+		if err != nil {
+			return nil, fmt.Errorf("pattern <%s>: %v", name, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match <%s>", name)
+		}
+		names = matches
+	}
+
+	files := []string{}
+	for _, n := range names {
+		if info, err := os.Stat(n); err != nil || !info.IsDir() {
+			files = append(files, n)
+			continue
+		}
+
+		// WalkDir goes in lexical order, hidden files and directories are skipped
+		err := filepath.WalkDir(n, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if path != n && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if d.Type().IsRegular() {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files in <%s>", name)
+	}
+
+	return files, nil
+}
+
+// setupSources expands the in option
+func (g *Generator) setupSources(errs *ValidationErrors) {
+	o := &g.opts
+
+	if o.Input == "" {
+		return
+	}
+
+	sources, err := ParseSources(o.Input)
+	if err != nil {
+		errs.addCause("in", o.Input, err)
+		return
+	}
+	g.sources = sources
+
+	if g.weighted() && (o.NR || o.Rank != "" || o.Emphasis > 0 || o.Pseudo || o.Confusable > 0 || o.Sentences || o.NGram > 0) {
+		errs.add("in", o.Input, "source weights can't be used with NR, rank, emphasis, pseudo, confusable, sentences or ngram")
+	}
+}
+
+// weighted is true when the sources of the in option have weights
+func (g *Generator) weighted() bool {
+	return len(g.sources) > 0 && g.sources[0].Weight > 0
+}
+
+// openInput returns every file of every source one after the other
+func (g *Generator) openInput() (io.Reader, func(), error) {
+	files := []string{}
+	for _, src := range g.sources {
+		files = append(files, src.Files...)
+	}
+
+	return g.openFiles(files)
+}
+
+// openFiles returns the files one after the other, each ending a line so
+// words don't join across files, and a func to close them. Stdin is read
+// once, and kept, as a setup like profile=corpus reads the input too.
+func (g *Generator) openFiles(names []string) (io.Reader, func(), error) {
+	opened := []*os.File{}
+	closeFiles := func() {
+		for _, f := range opened {
+			f.Close()
+		}
+	}
+
+	readers := []io.Reader{}
+	for _, name := range names {
+		if name == "-" {
+			if g.opts.Stdin == nil {
+				closeFiles()
+				return nil, nil, fmt.Errorf("there is no stdin to read for the in source \"-\"")
+			}
+
+			if g.stdinText == nil {
+				text, err := io.ReadAll(g.opts.Stdin)
+				if err != nil {
+					closeFiles()
+					return nil, nil, fmt.Errorf("%w reading stdin", err)
+				}
+				g.stdinText = append(text, '\n')
+			}
+			readers = append(readers, bytes.NewReader(g.stdinText))
+			continue
+		}
+
+		file, err := os.Open(name)
+		if err != nil {
+			closeFiles()
+			return nil, nil, fmt.Errorf("%w File name <%s>", err, name)
+		}
+		opened = append(opened, file)
+		readers = append(readers, file, strings.NewReader("\n"))
+	}
+
+	return io.MultiReader(readers...), closeFiles, nil
+}
+
+// sourceWords chooses num words, each source its weight's share
+func (g *Generator) sourceWords() []string {
+	pools := make([][]string, len(g.sources))
+
+	// a prosign is from no source, it goes with the first
+	for _, w := range g.wordOrder {
+		src := g.wordSource[w]
+		pools[src] = append(pools[src], w)
+	}
+
+	sizes := make([]int, len(pools))
+	for i, pool := range pools {
+		sizes[i] = len(pool)
+	}
+	counts := g.sourceCounts(sizes)
+
+	words := make([]string, 0, g.opts.Num)
+	for i, pool := range pools {
+		if g.opts.Unique {
+			g.shuffle(pool)
+			words = append(words, pool[:counts[i]]...)
+		} else {
+			words = append(words, g.drawWords(pool, counts[i])...)
+		}
+	}
+
+	g.shuffle(words)
+
+	g.wordMap = nil
+	g.wordOrder = nil
+	return words
+}
+
+// sourceCounts returns how many words each source gives, its weight's share
+// of num. A source with no words, or with unique too few, leaves what it
+// can't give to the others, shared by their weights the same way.
+func (g *Generator) sourceCounts(sizes []int) []int {
+	limit := make([]int, len(sizes))
+	for i, size := range sizes {
+		limit[i] = size
+		if !g.opts.Unique && size > 0 {
+			limit[i] = g.opts.Num
+		}
+	}
+
+	counts := make([]int, len(sizes))
+	left := g.opts.Num
+
+	// each round gives out all that's left, unless a source runs out
+	for left > 0 {
+		total := 0
+		for i, src := range g.sources {
+			if counts[i] < limit[i] {
+				total += src.Weight
+			}
+		}
+		if total == 0 {
+			break
+		}
+
+		cum := 0
+		placed := 0
+		given := 0
+		for i, src := range g.sources {
+			if counts[i] >= limit[i] {
+				continue
+			}
+
+			cum += src.Weight
+			end := (left*cum + total/2) / total
+			n := end - placed
+			placed = end
+
+			if n > limit[i]-counts[i] {
+				n = limit[i] - counts[i]
+			}
+			counts[i] += n
+			given += n
+		}
+		left -= given
+	}
+
+	return counts
+}
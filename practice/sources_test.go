@@ -0,0 +1,214 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSources(t *testing.T) {
+	tests := []struct {
+		in      string
+		names   []string
+		weights []int
+		err     bool
+	}{
+		{"a.txt", []string{"a.txt"}, []int{0}, false},
+		{"a.txt,b.txt", []string{"a.txt", "b.txt"}, []int{0, 0}, false},
+		{"a.txt:70, b.txt:30", []string{"a.txt", "b.txt"}, []int{70, 30}, false},
+		{"-:2,a.txt:1", []string{"-", "a.txt"}, []int{2, 1}, false},
+		{`C:\text.txt`, []string{`C:\text.txt`}, []int{0}, false},
+		{"a.txt:70,b.txt", nil, nil, true},
+		{"a.txt:0", nil, nil, true},
+		{"a.txt,,b.txt", nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		sources, err := ParseSources(tt.in)
+
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseSources(%q) = %v, want an error", tt.in, sources)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseSources(%q): %v", tt.in, err)
+			continue
+		}
+
+		if len(sources) != len(tt.names) {
+			t.Errorf("ParseSources(%q) has %d sources, want %d", tt.in, len(sources), len(tt.names))
+			continue
+		}
+
+		for i, src := range sources {
+			if src.Name != tt.names[i] || src.Weight != tt.weights[i] {
+				t.Errorf("ParseSources(%q)[%d] = %s:%d, want %s:%d", tt.in, i, src.Name, src.Weight, tt.names[i], tt.weights[i])
+			}
+		}
+	}
+}
+
+func TestParseSourcesFileNames(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a,b.txt", "notes:10", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("apple"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ab := filepath.Join(dir, "a,b.txt")
+	notes := filepath.Join(dir, "notes:10")
+	c := filepath.Join(dir, "c.txt")
+
+	tests := []struct {
+		in      string
+		names   []string
+		weights []int
+	}{
+		{ab, []string{ab}, []int{0}},
+		{notes, []string{notes}, []int{0}},
+		{ab + ":30," + c + ":70", []string{ab, c}, []int{30, 70}},
+		{c + "," + ab, []string{c, ab}, []int{0, 0}},
+		{notes + ":2," + c + ":1", []string{notes, c}, []int{2, 1}},
+	}
+
+	for _, tt := range tests {
+		sources, err := ParseSources(tt.in)
+		if err != nil {
+			t.Errorf("ParseSources(%q): %v", tt.in, err)
+			continue
+		}
+
+		if len(sources) != len(tt.names) {
+			t.Errorf("ParseSources(%q) has %d sources, want %d", tt.in, len(sources), len(tt.names))
+			continue
+		}
+
+		for i, src := range sources {
+			if src.Name != tt.names[i] || src.Weight != tt.weights[i] {
+				t.Errorf("ParseSources(%q)[%d] = %s:%d, want %s:%d", tt.in, i, src.Name, src.Weight, tt.names[i], tt.weights[i])
+			}
+		}
+	}
+}
+
+func TestSourceWeights(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.txt": "apple banana cherry",
+		"b.txt": "dog eagle fox",
+		"c.txt": "1234 5678", // no inlist words
+	}
+	for name, text := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	c := filepath.Join(dir, "c.txt")
+
+	tests := []struct {
+		in     string
+		num    int
+		unique bool
+		fromA  int
+		fromB  int
+	}{
+		{a + ":3," + b + ":1", 40, false, 30, 10},
+		{a + ":1," + b + ":1", 40, false, 20, 20},
+		{a + ":1," + b + ":3", 10, false, 3, 7},
+		{a + ":9," + b + ":1", 6, true, 3, 3},
+		{a + ":1," + b + ":1", 10, true, 3, 3},
+		{a + ":1," + b + ":5", 4, true, 1, 3},
+		{a + ":1," + c + ":1", 10, false, 10, 0},
+	}
+
+	for _, tt := range tests {
+		opts := DefaultOptions()
+		opts.Input = tt.in
+		opts.Num = tt.num
+		opts.Unique = tt.unique
+		opts.Seed = 1
+
+		g, err := New(opts)
+		if err != nil {
+			t.Fatalf("%s: New: %v", tt.in, err)
+		}
+
+		text, err := g.Generate()
+		if err != nil {
+			t.Fatalf("%s: Generate: %v", tt.in, err)
+		}
+
+		fromA, fromB := 0, 0
+		for _, w := range strings.Fields(text) {
+			if strings.Contains(files["a.txt"], w) {
+				fromA++
+			} else {
+				fromB++
+			}
+		}
+
+		if fromA != tt.fromA || fromB != tt.fromB {
+			t.Errorf("%s num %d unique %v: %d words from a, %d from b, want %d and %d",
+				tt.in, tt.num, tt.unique, fromA, fromB, tt.fromA, tt.fromB)
+		}
+	}
+}
+
+func TestStdin(t *testing.T) {
+	text, err := os.ReadFile(testText)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := testOptions()
+	opts.Input = testText
+	want := generate(t, opts)
+
+	opts.Input = "-"
+	opts.Stdin = strings.NewReader(string(text))
+	g, got := generator(t, opts)
+	if got != want {
+		t.Errorf("in - = %q\nwant the in file's %q", got, want)
+	}
+
+	// stdin is read once and kept for the next session
+	again, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == "" {
+		t.Errorf("the second session from stdin is empty")
+	}
+
+	opts.Input = "-:1," + testText + ":1"
+	opts.Stdin = strings.NewReader("zebra")
+	opts.Num = 10
+	if words := strings.Fields(generate(t, opts)); strings.Count(strings.Join(words, " "), "zebra") != 5 {
+		t.Errorf("in %s = %v, want zebra for half the words", opts.Input, words)
+	}
+}
+
+func TestStdinMissing(t *testing.T) {
+	opts := testOptions()
+	opts.Input = "-"
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = g.Generate()
+	if err == nil || !strings.Contains(err.Error(), "no stdin") {
+		t.Errorf("in - with no Stdin: %v, want the no stdin error", err)
+	}
+}
@@ -0,0 +1,65 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is one option that broke a rule.
+type FieldError struct {
+	Field string      // option name as typed on the command line, e.g. "min" or "EB_LOW"
+	Value interface{} // the value that was given
+	Rule  string      // the rule it broke
+	Err   error       // the cause, such as a file that can't be read, or nil
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("option <%s> value <%v>: %s", e.Field, e.Value, e.Rule)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors holds every problem New found, so they can all be
+// reported in one run instead of one per run.
+type ValidationErrors []*FieldError
+
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(v))
+
+	for _, e := range v {
+		msgs = append(msgs, e.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// add a problem, rule is a format string for args
+func (v *ValidationErrors) add(field string, value interface{}, rule string, args ...interface{}) {
+	*v = append(*v, &FieldError{Field: field, Value: value, Rule: fmt.Sprintf(rule, args...)})
+}
+
+// add a problem caused by err, errors.As still finds a *fs.PathError in it
+func (v *ValidationErrors) addCause(field string, value interface{}, err error) {
+	*v = append(*v, &FieldError{Field: field, Value: value, Rule: err.Error(), Err: err})
+}
+
+// keep err, which must be a *FieldError from a list helper
+func (v *ValidationErrors) addErr(err error) {
+	if fe, ok := err.(*FieldError); ok {
+		*v = append(*v, fe)
+		return
+	}
+
+	v.add("", "", "%v", err)
+}
+
+// error for a list option
+func listError(whoAmI string, value string, rule string, args ...interface{}) error {
+	return &FieldError{Field: whoAmI, Value: value, Rule: fmt.Sprintf(rule, args...)}
+}
@@ -0,0 +1,148 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestValidationErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		set   func(o *Options)
+		field string
+		value string
+		rule  string // part of the rule
+	}{
+		{"range", func(o *Options) { o.Num = 0 }, "num", "0", "minimum 1"},
+		{"range", func(o *Options) { o.Len = 600 }, "len", "600", "maximum 500"},
+		{"range", func(o *Options) { o.Skip = 6000 }, "skip", "6000", "maximum 5000"},
+		{"range", func(o *Options) { o.DM = 30 }, "DM", "30", "max <= 20"},
+		{"range", func(o *Options) { o.MixedMode = 30 }, "mixedMode", "30", "maximum 20"},
+		{"order", func(o *Options) { o.Min, o.Max = 5, 3 }, "min", "5", "must be <= max <3>"},
+		{"order", func(o *Options) { o.CGMin, o.CGMax = 6, 5 }, "cgmax", "5", "must be >= cgmin <6>"},
+		{"list", func(o *Options) { o.CodeGroups, o.Cglist = true, "z-a" }, "cglist", "z-a", "not in ASCII/UTF-8 order"},
+		{"list", func(o *Options) { o.Delimiter, o.DM = "<B>", 1 }, "delimiter", "<B>", "invalid prosign format"},
+		{"lesson", func(o *Options) { o.Lesson = 50 }, "lesson", "50", "exceeds the max <40>"},
+		{"requires", func(o *Options) { o.WordCount = 3 }, "wordCount", "3", "requires the repeat option"},
+		{"modes", func(o *Options) { o.CodeGroups, o.Callsigns = true, true }, "callsigns", "true", "only one of"},
+		{"ebook", func(o *Options) { o.EBLow, o.EBStep, o.EBNum = 0, 5, 2 }, "EB_LOW", "0", "at least 5 wpm"},
+		{"qso", func(o *Options) { o.QSO, o.Num = true, 101 }, "num", "101", "number of QSOs, maximum 100"},
+		{"contest", func(o *Options) { o.Contest = "XX" }, "contest", "XX", "choices are (case insensitive): CQWW, FD"},
+		{"contest", func(o *Options) { o.Cut = true }, "cut", "true", "requires the contest option"},
+		{"vocab", func(o *Options) { o.Vocab = "q,xx" }, "vocab", "q,xx", "set <xx> is invalid"},
+		{"vocab", func(o *Options) { o.Vocab, o.VocabRatio = "q", 0 }, "vocabRatio", "0", "between 1 and 100"},
+		{"vocab", func(o *Options) { o.Vocab, o.NR = "q", true }, "vocab", "q", "mutually exclusive with the NR option"},
+		{"profile", func(o *Options) { o.CodeGroups, o.Profile = true, "zipf" }, "profile", "zipf", "choices are (case insensitive): uniform"},
+		{"profile", func(o *Options) { o.Profile = "english" }, "profile", "english", "requires codeGroups or mixedMode"},
+		{"profile", func(o *Options) { o.CodeGroups, o.Profile = true, "corpus" }, "profile", "corpus", "requires an in file"},
+		{"profile", func(o *Options) { o.CodeGroups, o.Profile = true, "file" }, "profile", "file", "requires a profileFile"},
+		{"profile", func(o *Options) { o.CodeGroups, o.ProfileFile = true, "p.txt" }, "profileFile", "p.txt", "requires profile=file"},
+		{"ngram", func(o *Options) { o.Input, o.NGram = testText, 1 }, "ngram", "1", "minimum 2, maximum 5"},
+		{"ngram", func(o *Options) { o.Input, o.NGram, o.NGramTop = testText, 2, 0 }, "ngramTop", "0", "minimum 1"},
+		{"ngram", func(o *Options) { o.NGramGroups = true }, "ngramGroups", "true", "requires the ngram option"},
+		{"ngram", func(o *Options) { o.Input, o.NGram, o.NGramGroups, o.CGMax = testText, 4, true, 3 }, "cgmax", "3", "must be >= ngram <4>"},
+		{"ngram", func(o *Options) { o.NGram = 2 }, "in", "", "an input file must be given"},
+		{"ngram", func(o *Options) { o.Input, o.NGram, o.NR = testText, 2, true }, "ngram", "true", "mutually exclusive with NR and vocab"},
+		{"confusable", func(o *Options) { o.CodeGroups, o.Confusable = true, 21 }, "confusable", "21", "maximum 20"},
+		{"confusable", func(o *Options) { o.Input, o.Sentences, o.Confusable = testText, true, 3 }, "confusable", "3", "not NR or the other modes"},
+		{"dits", func(o *Options) { o.MinDits, o.MaxDits = 20, 10 }, "minDits", "20", "must be <= maxDits <10>"},
+		{"dits", func(o *Options) { o.DitOrder = "up" }, "ditOrder", "up", "choices are asc or desc"},
+		{"dits", func(o *Options) { o.Input, o.Sentences, o.MaxDits = testText, true, 50 }, "minDits", "0", "are for words from the in file"},
+		{"sentences", func(o *Options) { o.Sentences = true }, "in", "", "an input file must be given"},
+		{"sentences", func(o *Options) { o.Input, o.Sentences, o.Vocab = testText, true, "q" }, "sentences", "true", "mutually exclusive with NR and vocab"},
+		{"numbers", func(o *Options) { o.Numbers = "rst,pi" }, "numbers", "rst,pi", "kind <pi> is invalid"},
+		{"numbers", func(o *Options) { o.NumMix = 3 }, "numMix", "3", "requires the numbers option"},
+		{"numbers", func(o *Options) { o.Numbers, o.NumMix = "rst", 1 }, "numMix", "1", "minimum 2"},
+		{"numbers", func(o *Options) { o.Numbers, o.NumMix, o.CodeGroups = "rst", 3, true }, "numMix", "3", "not with the other modes"},
+		{"rank", func(o *Options) { o.Rank = "best" }, "rank", "best", "choices are (case insensitive): top, band"},
+		{"rank", func(o *Options) { o.Rank, o.RankFrom, o.RankTo = "band", 5, 2 }, "rankFrom", "5", "must be >= 1 and <= rankTo <2>"},
+		{"rank", func(o *Options) { o.FreqList = "f.txt" }, "freqList", "f.txt", "requires the rank option"},
+		{"rank", func(o *Options) { o.Rank, o.NR = "top", true }, "rank", "top", "mutually exclusive with NR"},
+		{"emphasis", func(o *Options) { o.Lesson, o.Emphasis = 10, 101 }, "emphasis", "101", "<= 100 percent"},
+		{"emphasis", func(o *Options) { o.Emphasis = 50 }, "emphasis", "50", "requires the lesson option"},
+		{"emphasis", func(o *Options) { o.Lesson, o.Emphasis, o.Vocab = 10, 50, "q" }, "emphasis", "50", "not with NR, vocab"},
+		{"emphasis", func(o *Options) { o.Lesson, o.Emphasis, o.EmphasisChars = 2, 50, 4 }, "emphasisChars", "4", "the characters in the lesson <3>"},
+		{"dict", func(o *Options) { o.Dict = "xx" }, "dict", "xx", "choices are (case insensitive): english, ham, all"},
+		{"callsigns", func(o *Options) { o.Callsigns, o.Cglist = true, "abc" }, "callsigns", "true", "needs a digit"},
+	}
+
+	for _, tt := range tests {
+		opts := testOptions()
+		tt.set(&opts)
+
+		_, err := New(opts)
+
+		var errs ValidationErrors
+		if !errors.As(err, &errs) {
+			t.Errorf("%s %s: New error %v, want ValidationErrors", tt.name, tt.field, err)
+			continue
+		}
+
+		var found *FieldError
+		for _, e := range errs {
+			if e.Field == tt.field {
+				found = e
+			}
+		}
+
+		if found == nil {
+			t.Errorf("%s %s: %v has no error for the field", tt.name, tt.field, errs)
+			continue
+		}
+
+		if fmt.Sprint(found.Value) != tt.value || !strings.Contains(found.Rule, tt.rule) {
+			t.Errorf("%s %s: value <%v> rule %q, want value <%s> and rule with %q",
+				tt.name, tt.field, found.Value, found.Rule, tt.value, tt.rule)
+		}
+
+		if want := fmt.Sprintf("option <%s> value <%v>: %s", found.Field, found.Value, found.Rule); found.Error() != want {
+			t.Errorf("%s %s: Error() = %q, want %q", tt.name, tt.field, found.Error(), want)
+		}
+	}
+}
+
+func TestValidationErrorsAll(t *testing.T) {
+	// every problem is reported, not just the first
+	opts := testOptions()
+	opts.Num = 0
+	opts.Len = 600
+	opts.Skip = 6000
+
+	_, err := New(opts)
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("New error %v, want 3 ValidationErrors", err)
+	}
+
+	if lines := strings.Split(errs.Error(), "\n"); len(lines) != 3 {
+		t.Errorf("Error() has %d lines, want one per problem:\n%s", len(lines), errs.Error())
+	}
+}
+
+func TestValidationErrorCause(t *testing.T) {
+	// a file that can't be read keeps its *fs.PathError
+	opts := testOptions()
+	opts.CodeGroups = true
+	opts.Profile = "file"
+	opts.ProfileFile = "testdata/no such file"
+
+	_, err := New(opts)
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("New error %v, want one ValidationError", err)
+	}
+
+	var pathErr *fs.PathError
+	if errs[0].Field != "profileFile" || !errors.As(errs[0], &pathErr) {
+		t.Errorf("got %#v, want a profileFile error wrapping *fs.PathError", errs[0])
+	}
+}
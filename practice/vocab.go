@@ -0,0 +1,163 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// built-in vocabulary, set name to word and its meaning
+var vocabulary = map[string]map[string]string{
+	"q": {
+		"qrl": "is the frequency in use", "qrm": "interference", "qrn": "static",
+		"qro": "increase power", "qrp": "low power", "qrq": "send faster",
+		"qrs": "send slower", "qrt": "stop sending", "qru": "nothing for you",
+		"qrv": "ready", "qrx": "wait", "qrz": "who is calling",
+		"qsb": "fading", "qsk": "break-in", "qsl": "acknowledge receipt",
+		"qso": "contact", "qst": "general call to all amateurs", "qsy": "change frequency",
+		"qtc": "traffic", "qth": "location", "qtr": "correct time",
+	},
+	"abbr": {
+		"abt": "about", "agn": "again", "ant": "antenna", "bk": "break",
+		"b4": "before", "cfm": "confirm", "cl": "closing", "cq": "calling any station",
+		"cul": "see you later", "cuagn": "see you again", "de": "from", "dr": "dear",
+		"dx": "distance", "es": "and", "fb": "fine business", "fer": "for",
+		"ga": "good afternoon", "gd": "good day", "ge": "good evening", "gm": "good morning",
+		"gn": "good night", "hi": "laughter", "hpe": "hope", "hr": "here",
+		"hw?": "how copy", "nr": "number", "om": "old man", "op": "operator",
+		"pse": "please", "pwr": "power", "r": "roger", "rcvd": "received",
+		"rpt": "report", "rst": "readability strength tone", "sig": "signal", "sri": "sorry",
+		"tnx": "thanks", "tu": "thank you", "u": "you", "ur": "your",
+		"vy": "very", "wx": "weather", "xyl": "wife", "yl": "young lady",
+		"73": "best regards", "88": "love and kisses", "5nn": "599 signal report",
+	},
+	"ham": {
+		"balun": "balanced to unbalanced transformer", "beam": "directional antenna",
+		"bug": "semi-automatic key", "coax": "coaxial cable", "dipole": "two element wire antenna",
+		"dxpedition": "trip to operate from a rare place", "elmer": "mentor", "keyer": "electronic key",
+		"net": "scheduled on air meeting", "paddle": "keyer lever", "ragchew": "long casual contact",
+		"repeater": "relay station", "rig": "radio equipment", "sked": "schedule",
+		"swr": "standing wave ratio", "tuner": "antenna matching unit", "yagi": "beam antenna",
+		"contest": "on air competition", "hamfest": "ham radio flea market", "skip": "ionospheric propagation",
+	},
+}
+
+// VocabSets returns the -vocab choices, all is every set.
+func VocabSets() []string {
+	names := make([]string, 0, len(vocabulary))
+	for name := range vocabulary {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// setupVocab checks the -vocab set names, "all" is every set
+func (g *Generator) setupVocab(errs *ValidationErrors) {
+	o := &g.opts
+
+	if o.Vocab == "" {
+		return
+	}
+
+	if o.NR {
+		errs.add("vocab", o.Vocab, "mutually exclusive with the NR option")
+	}
+
+	if o.VocabRatio < 1 || o.VocabRatio > 100 {
+		errs.add("vocabRatio", o.VocabRatio, "percent of words from the vocabulary when in is also used, between 1 and 100")
+	}
+
+	o.Vocab = strings.ToLower(o.Vocab)
+	g.vocab = make(map[string]string)
+
+	for _, name := range strings.Split(o.Vocab, ",") {
+		name = strings.TrimSpace(name)
+
+		if name == "all" {
+			for _, set := range vocabulary {
+				for w, m := range set {
+					g.vocab[w] = m
+				}
+			}
+			continue
+		}
+
+		set, ok := vocabulary[name]
+		if !ok {
+			errs.add("vocab", o.Vocab, "set <%s> is invalid, choices are a comma list of: %s, or all", name, strings.Join(VocabSets(), ", "))
+			continue
+		}
+
+		for w, m := range set {
+			g.vocab[w] = m
+		}
+	}
+}
+
+// vocabWords returns the vocabulary words of min to max characters, all of
+// them inlist or cglist (the lesson if given), so digits and punctuation
+// like "73" and "hw?" are kept as in a sentence. The order is sorted so the
+// seed gives the same words.
+func (g *Generator) vocabWords() []string {
+	allowed := g.sendable()
+	words := []string{}
+
+	for w := range g.vocab {
+		n := utf8.RuneCountInString(w)
+		if n < g.opts.Min || n > g.opts.Max || strings.IndexFunc(w, func(r rune) bool { return !allowed(r) }) >= 0 || !g.ditsOK(w) {
+			continue
+		}
+
+		if g.opts.Caps {
+			w = strings.ToUpper(w)
+		}
+		words = append(words, w)
+	}
+	sort.Strings(words)
+
+	return words
+}
+
+// mixVocab puts vocabulary words in place of the -vocabRatio percent of words
+func (g *Generator) mixVocab(words []string, vocab []string) []string {
+	if len(vocab) == 0 {
+		return words
+	}
+
+	n := len(words) * g.opts.VocabRatio / 100
+	if g.opts.Unique && n > len(vocab) {
+		n = len(vocab)
+	}
+
+	g.shuffle(vocab)
+	places := g.rng.Perm(len(words))[:n]
+
+	for i, place := range places {
+		words[place] = vocab[i%len(vocab)]
+	}
+
+	return words
+}
+
+// meanings of the vocabulary words in text, first heard first
+func (g *Generator) meanings(words []string) []string {
+	lines := []string{}
+	seen := make(map[string]bool)
+
+	for _, w := range words {
+		lw := strings.ToLower(w)
+		if m, ok := g.vocab[lw]; ok && !seen[lw] {
+			seen[lw] = true
+			lines = append(lines, fmt.Sprintf("%-10s %s", w, m))
+		}
+	}
+
+	return lines
+}
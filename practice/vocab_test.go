@@ -0,0 +1,96 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVocab(t *testing.T) {
+	opts := testOptions()
+	opts.Vocab = "q,abbr"
+	opts.Num = 30
+
+	checkGolden(t, "vocab", generate(t, opts))
+}
+
+func TestVocabWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		set   func(o *Options)
+		words int    // different words sent
+		must  string // words that must be sent
+	}{
+		{"every entry", func(o *Options) {}, len(vocabulary["abbr"]), "73 88 5nn b4 hw? r"},
+		{"min max", func(o *Options) { o.Min, o.Max = 3, 3 }, -1, "5nn hw? cfm"},
+		{"lesson", func(o *Options) { o.Lesson = 5 }, -1, "r u ur es"},
+	}
+
+	for _, tt := range tests {
+		opts := testOptions()
+		opts.Vocab = "abbr"
+		opts.Num = 2000
+		tt.set(&opts)
+
+		g, text := generator(t, opts)
+		sent := make(map[string]bool)
+		for _, w := range strings.Fields(text) {
+			sent[w] = true
+
+			if _, ok := g.vocab[w]; !ok {
+				t.Errorf("%s: %s is not an abbr word", tt.name, w)
+			}
+			if n := len([]rune(w)); n < opts.Min || n > opts.Max {
+				t.Errorf("%s: %s is not %d to %d characters", tt.name, w, opts.Min, opts.Max)
+			}
+		}
+
+		if tt.words >= 0 && len(sent) != tt.words {
+			t.Errorf("%s: %d words sent, want %d", tt.name, len(sent), tt.words)
+		}
+		for _, w := range strings.Fields(tt.must) {
+			if !sent[w] {
+				t.Errorf("%s: %s not sent", tt.name, w)
+			}
+		}
+	}
+}
+
+func TestVocabRatio(t *testing.T) {
+	opts := testOptions()
+	opts.Input = testText
+	opts.Vocab = "q"
+	opts.VocabRatio = 40
+	opts.Num = 100
+
+	n := 0
+	for _, w := range strings.Fields(generate(t, opts)) {
+		if _, ok := vocabulary["q"][w]; ok {
+			n++
+		}
+	}
+
+	if n != 40 {
+		t.Errorf("%d q words of %d, want vocabRatio %d%%", n, opts.Num, opts.VocabRatio)
+	}
+}
+
+func TestVocabMeanings(t *testing.T) {
+	opts := testOptions()
+	opts.Vocab = "q"
+
+	g, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := g.AnswerKey("qth qrs abc qth")
+	want := "  1  qth qrs abc qth\n\nMeanings:\n     qth        location\n     qrs        send slower\n"
+
+	if key != want {
+		t.Errorf("AnswerKey = %q, want %q", key, want)
+	}
+}
@@ -0,0 +1,116 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"math"
+	"sort"
+	"unicode"
+
+	"github.com/wa2nfn/cwpt/score"
+)
+
+// setupWeights reads the progress file for -weak, so code group, callsign,
+// prefix and suffix characters are drawn more often the worse they have been copied
+func (g *Generator) setupWeights(errs *ValidationErrors) {
+	o := &g.opts
+
+	if !o.Weak {
+		return
+	}
+
+	if o.Progress == "" {
+		errs.add("weak", o.Weak, "requires a progress file name")
+		return
+	}
+
+	if !o.CodeGroups && !o.Callsigns && o.MixedMode == 0 && o.Prefix == 0 && o.Suffix == 0 {
+		errs.add("weak", o.Weak, "requires codeGroups, mixedMode, callsigns, prefix or suffix, it only weights those characters")
+		return
+	}
+
+	p, err := score.LoadProgress(o.Progress)
+	if err != nil {
+		errs.addCause("progress", o.Progress, err)
+		return
+	}
+
+	g.weights = make(map[rune]float64)
+	for _, list := range [][]rune{g.cglistRune, g.prelistRune, g.suflistRune} {
+		for _, r := range list {
+			g.weights[unicode.ToLower(r)] = p.Weight(string(r))
+		}
+	}
+}
+
+// weight of r, either case, a character not in the lists counts as 1
+func (g *Generator) weight(r rune) float64 {
+	if w, ok := g.weights[unicode.ToLower(r)]; ok {
+		return w
+	}
+
+	return 1
+}
+
+// pickRune returns a random rune of list, weighted if -weak
+func (g *Generator) pickRune(list []rune) rune {
+	if g.weights == nil {
+		return list[g.rng.Intn(len(list))]
+	}
+
+	return list[g.weightedIndex(len(list), func(i int) float64 { return g.weight(list[i]) })]
+}
+
+// weightedCharSlice has each cglist character in proportion to its -weak
+// and -profile weights, rounded up so there are at least numChars
+func (g *Generator) weightedCharSlice(numChars int) []rune {
+	total := 0.0
+	for _, r := range g.cglistRune {
+		total += g.weight(r) * g.profileWeight(r)
+	}
+
+	charSlice := make([]rune, 0, numChars+len(g.cglistRune))
+
+	for _, r := range g.cglistRune {
+		n := int(math.Ceil(g.weight(r) * g.profileWeight(r) / total * float64(numChars)))
+		for ; n > 0; n-- {
+			charSlice = append(charSlice, r)
+		}
+	}
+
+	return charSlice
+}
+
+// weightedIndex returns a random index below n, each as likely as its weight
+func (g *Generator) weightedIndex(n int, weight func(i int) float64) int {
+	total := 0.0
+	for i := 0; i < n; i++ {
+		total += weight(i)
+	}
+
+	pick := g.rng.Float64() * total
+	for i := 0; i < n; i++ {
+		pick -= weight(i)
+		if pick < 0 {
+			return i
+		}
+	}
+
+	return n - 1
+}
+
+// weightedShuffle orders words, each different, at random, a word of more
+// weight more likely to come first. Each gets the key rand^(1/weight) and
+// the biggest key goes first.
+func (g *Generator) weightedShuffle(words []string, weight func(w string) float64) {
+	key := make(map[string]float64, len(words))
+	for _, w := range words {
+		key[w] = math.Pow(g.rng.Float64(), 1/weight(w))
+	}
+
+	sort.SliceStable(words, func(i, j int) bool {
+		return key[words[i]] > key[words[j]]
+	})
+}
@@ -0,0 +1,352 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package practice
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/wa2nfn/cwpt/koch"
+)
+
+/*
+** added for Ordered write of input "NR"
+ */
+
+// read input file and create words. vs do code groups
+func (g *Generator) readFileMode() ([]string, error) {
+	done := false
+	discarded := false
+	localSkipFlag := false
+	localSkipCount := 0
+
+	if g.opts.Skip >= 1 {
+		// we will be skipping some words
+		localSkipFlag = true
+		localSkipCount = g.opts.Skip
+	}
+
+	// with only -vocab there is no input file, else the built-in words are used
+	ins := []io.Reader{strings.NewReader("")}
+
+	if g.opts.Input == "" && g.opts.Vocab == "" {
+		dict, err := g.dictReader()
+		if err != nil {
+			return nil, err
+		}
+		ins = []io.Reader{dict}
+	} else if g.opts.Input != "" {
+		// one reader per source, a word belongs to the first source it is in
+		ins = nil
+		for _, src := range g.sources {
+			in, closeFiles, err := g.openFiles(src.Files)
+			if err != nil {
+				return nil, err
+			}
+			defer closeFiles()
+			ins = append(ins, in)
+		}
+	}
+
+	// to match what user wants
+	s := fmt.Sprintf(`^[%s]{%d,%d}$|^(<[A-Za-z]{2}>){1,}$`, g.opts.Inlist, g.opts.Min, g.opts.Max)
+	word := regexp.MustCompile(s)
+
+	// read ProSigns
+	if g.opts.Prosign != "" {
+		psfile, err := os.Open(g.opts.Prosign)
+		if err != nil {
+			return nil, fmt.Errorf("%s File name <%s>", err, g.opts.Prosign)
+		}
+
+		// fill the map with prosigns
+		g.doProSigns(psfile)
+		psfile.Close()
+	}
+
+	for src, in := range ins {
+		scanner := koch.NewScanner(in)
+
+		for scanner.Scan() {
+			// first way to split the string on spaces
+			textWords := koch.Words(scanner.Text())
+
+			for index := 0; done == false && index < len(textWords); index++ {
+				// every token is now a string of space separated characters
+				tmpWord := koch.TrimWord(textWords[index])
+
+				if word.MatchString(tmpWord) && g.ditsOK(tmpWord) {
+
+					// skip only viable matching words
+					if localSkipFlag {
+						if localSkipCount > 0 {
+							localSkipCount--
+							continue
+						} else {
+							localSkipFlag = false
+						}
+					}
+
+					// set case before storing
+					if g.opts.Caps {
+						tmpWord = strings.ToUpper(tmpWord)
+					} else {
+						tmpWord = strings.ToLower(tmpWord)
+					}
+
+					// reverse the string
+					if g.opts.Reverse {
+						tmpWord = reverse(tmpWord)
+					}
+
+					/*
+					** if -NR words are ordered so we store and retrieve from an array
+					** else we use a map
+					 */
+					if g.opts.NR {
+						g.wordArray = append(g.wordArray, tmpWord)
+						if len(g.wordArray) == g.opts.Num {
+							done = true
+
+						}
+
+					} else {
+						// add to map if not there
+						g.addWord(tmpWord)
+
+						// the built-in words are most frequent first, like a freqList without counts
+						if g.opts.Input != "" {
+							if _, ok := g.wordSource[tmpWord]; !ok {
+								g.wordSource[tmpWord] = src
+							}
+							g.wordFreq[tmpWord]++
+						} else if g.wordFreq[tmpWord] == 0 {
+							g.wordFreq[tmpWord] = 1 / float64(len(g.wordOrder))
+						}
+					}
+				} else {
+					discarded = true
+				}
+			}
+
+			// proSigns for NR = false done differently
+			if g.opts.NR && g.opts.Prosign != "" && len(g.wordArray) >= len(g.proSign) {
+				replaceIndex := make(map[int]struct{})
+				indexes := []int{}
+
+				for i := 0; i < len(g.proSign); {
+					rand := g.rng.Intn(len(g.wordArray))
+					if _, ok := replaceIndex[rand]; ok != true {
+						replaceIndex[rand] = struct{}{}
+						indexes = append(indexes, rand)
+						i++
+					}
+				}
+
+				// now do the substitions
+				j := 0
+				for _, index := range indexes {
+					temp := append([]string{g.proSign[j]}, g.wordArray[index:]...)
+					g.wordArray = append(g.wordArray[:index], temp...)
+					j++
+				}
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		if done {
+			break
+		}
+	}
+
+	if g.opts.NR {
+
+		if len(g.wordArray) == 0 {
+			return nil, g.nothingToOutput(discarded, localSkipFlag)
+		}
+
+		ct := len(g.wordArray)
+		if ct < g.opts.Num {
+			ct = g.opts.Num - ct
+			// we need to append more words in order
+			for i := 0; i < ct; i++ {
+				g.wordArray = append(g.wordArray, g.wordArray[i])
+			}
+		}
+
+		return g.wordArray, nil
+	}
+
+	var vocab []string
+	if g.vocab != nil {
+		vocab = g.vocabWords()
+
+		if g.opts.Input == "" {
+			for _, w := range vocab {
+				g.addWord(w)
+			}
+		}
+	}
+
+	if len(g.wordMap) == 0 {
+		return nil, g.nothingToOutput(discarded, localSkipFlag)
+	}
+
+	if g.opts.Pseudo {
+		pseudo := g.makePseudoWords(g.wordOrder)
+		if len(pseudo) == 0 {
+			return nil, g.nothingToOutput(true, localSkipFlag)
+		}
+		return pseudo, nil
+	}
+
+	if g.opts.Rank != "" {
+		sample, err := g.rankWords()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(g.wordOrder) == 0 || g.opts.Rank == "sample" && len(sample) == 0 {
+			return nil, g.nothingToOutput(true, localSkipFlag)
+		}
+
+		if sample != nil {
+			return sample, nil
+		}
+	}
+
+	if g.newChars != nil {
+		return g.emphasizeWords()
+	}
+
+	if g.weighted() {
+		words := g.sourceWords()
+		if len(vocab) > 0 {
+			return g.mixVocab(words, vocab), nil
+		}
+		return words, nil
+	}
+
+	// the entire input is randomized then trimmed to save time and memory later
+	if g.pairs != nil {
+		g.confusableOrder(g.wordOrder)
+	} else {
+		g.shuffle(g.wordOrder)
+	}
+	if len(g.wordOrder) > g.opts.Num {
+		g.wordOrder = g.wordOrder[:g.opts.Num]
+	}
+
+	if g.opts.Input != "" && len(vocab) > 0 {
+		return g.mixVocab(g.fillArray(), vocab), nil
+	}
+
+	return g.fillArray(), nil
+}
+
+// hints for the user on why nothing matched
+func (g *Generator) nothingToOutput(discarded bool, skipping bool) error {
+	hint := ""
+
+	if discarded {
+		hint += "\n\nYour input file DID have some text."
+		if skipping {
+			hint += "\nYour -skip X option maybe too aggresive."
+		}
+	}
+
+	return fmt.Errorf("%w%s", ErrNothingToOutput, hint)
+}
+
+// add a word to the map if not there, remembering the order read
+func (g *Generator) addWord(word string) {
+	if _, ok := g.wordMap[word]; ok != true {
+		g.wordMap[word] = struct{}{}
+		g.wordOrder = append(g.wordOrder, word)
+	}
+}
+
+// shuffle words in place, using the seeded rng
+func (g *Generator) shuffle(words []string) {
+	g.rng.Shuffle(len(words), func(i, j int) {
+		words[i], words[j] = words[j], words[i]
+	})
+}
+
+// fill the array from the word map but might need to stuff more values
+func (g *Generator) fillArray() []string {
+	var wordArray = make([]string, 0, g.opts.Num)
+
+	// make first population of slice
+	wordArray = append(wordArray, g.wordOrder...)
+
+	// see if initial array satisfies the number of words the user wanted
+	// if less, we will reuse words from map to grow the array (or slice)
+	if !g.opts.Unique && len(wordArray) < g.opts.Num {
+
+		factor := g.opts.Num / len(wordArray)
+		factor-- // we have the original already
+
+		// only does FULL maps, each in a new random order
+		for ; factor > 0; factor-- {
+			g.shuffle(g.wordOrder)
+			wordArray = append(wordArray, g.wordOrder...)
+		}
+
+		// may still be a partial shortage
+		howShort := g.opts.Num - len(wordArray)
+		g.shuffle(g.wordOrder)
+		wordArray = append(wordArray, g.wordOrder[:howShort]...)
+	}
+
+	g.wordMap = nil
+	g.wordOrder = nil
+	return wordArray
+}
+
+// process the file of prosigns, check their validity
+func (g *Generator) doProSigns(file io.Reader) {
+	ps := ""
+
+	scanner := bufio.NewScanner(file)
+
+	word := regexp.MustCompile("^\\s*(<[A-Za-z][A-Za-z]>){1,4}\\s*$|^\\s*<[Ss][Oo][Ss]>{1,4}\\s*$")
+
+	for scanner.Scan() {
+		ps = strings.TrimSpace(scanner.Text())
+
+		if word.MatchString(ps) {
+			if g.opts.Caps {
+				ps = strings.ToUpper(ps)
+			}
+
+			if g.opts.NR {
+				g.proSign = append(g.proSign, ps)
+			} else {
+				// add to map if not there
+				g.addWord(ps)
+			}
+
+		} // ignore non matching ProSigns
+	}
+}
+
+// reverse a string
+func reverse(s string) string {
+	rs := []rune(s)
+
+	for i, j := 0, len(rs)-1; i < j; i, j = i+1, j-1 {
+		rs[i], rs[j] = rs[j], rs[i]
+	}
+
+	return string(rs)
+}
@@ -0,0 +1,115 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package score
+
+// align finds the fewest substitutions, drops and insertions that turn
+// sent into copied. It is Hirschberg's method, the edit distance of each half
+// of sent is worked out a row at a time to find where copied splits, so a
+// long session, or a short copy of one, needs memory for a row, not a table.
+func align(sent []string, copied []string) []Op {
+	ops := []Op{}
+	return hirschberg(ops, sent, copied)
+}
+
+// hirschberg appends the ops that turn sent into copied
+func hirschberg(ops []Op, sent []string, copied []string) []Op {
+	n, m := len(sent), len(copied)
+
+	switch {
+	case n == 0:
+		for _, c := range copied {
+			ops = append(ops, Op{Kind: Insert, Copied: c})
+		}
+		return ops
+	case m == 0:
+		for _, s := range sent {
+			ops = append(ops, Op{Kind: Drop, Sent: s})
+		}
+		return ops
+	case n == 1:
+		return alignOne(ops, sent[0], copied)
+	}
+
+	// the split of copied that costs least with sent split in half
+	mid := n / 2
+	front := lastRow(sent[:mid], copied, false)
+	rear := lastRow(sent[mid:], copied, true)
+
+	split := 0
+	for j := 1; j <= m; j++ {
+		if front[j]+rear[m-j] < front[split]+rear[m-split] {
+			split = j
+		}
+	}
+
+	ops = hirschberg(ops, sent[:mid], copied[:split])
+	return hirschberg(ops, sent[mid:], copied[split:])
+}
+
+// alignOne lines a single sent character up with copied, on its first match
+// if there is one, else as a substitution for the first copied character
+func alignOne(ops []Op, s string, copied []string) []Op {
+	at := 0
+	kind := Sub
+	for j, c := range copied {
+		if c == s {
+			at, kind = j, Match
+			break
+		}
+	}
+
+	for _, c := range copied[:at] {
+		ops = append(ops, Op{Kind: Insert, Copied: c})
+	}
+	ops = append(ops, Op{Kind: kind, Sent: s, Copied: copied[at]})
+	for _, c := range copied[at+1:] {
+		ops = append(ops, Op{Kind: Insert, Copied: c})
+	}
+
+	return ops
+}
+
+// lastRow is the edit distance from sent to each prefix of copied, or with
+// reverse from the ends of both to each suffix of copied, a row at a time
+func lastRow(sent []string, copied []string, reverse bool) []int {
+	m := len(copied)
+	prev := make([]int, m+1)
+	cur := make([]int, m+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(sent); i++ {
+		s := sent[i-1]
+		if reverse {
+			s = sent[len(sent)-i]
+		}
+
+		cur[0] = i
+		for j := 1; j <= m; j++ {
+			c := copied[j-1]
+			if reverse {
+				c = copied[m-j]
+			}
+
+			best := prev[j-1]
+			if s != c {
+				best++
+			}
+			if prev[j]+1 < best {
+				best = prev[j] + 1
+			}
+			if cur[j-1]+1 < best {
+				best = cur[j-1] + 1
+			}
+			cur[j] = best
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev
+}
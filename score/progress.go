@@ -0,0 +1,118 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package score
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Progress is the running count of each character sent and copied
+// correctly, over every scored session.
+type Progress map[string]*CharStats
+
+// LoadProgress reads a progress file, a file that doesn't exist yet is no progress.
+// Each line is: character sent correct, a line starting with # is a comment.
+func LoadProgress(name string) (Progress, error) {
+	p := Progress{}
+
+	file, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		f := strings.Fields(line)
+		if len(f) != 3 {
+			return nil, fmt.Errorf("line <%d> of file <%s>: want \"character sent correct\"", lineNum, name)
+		}
+
+		sent, err1 := strconv.Atoi(f[1])
+		correct, err2 := strconv.Atoi(f[2])
+		if err1 != nil || err2 != nil || sent < 0 || correct < 0 || correct > sent {
+			return nil, fmt.Errorf("line <%d> of file <%s>: sent and correct must be numbers, correct <= sent", lineNum, name)
+		}
+
+		p.add(f[0], sent, correct)
+	}
+
+	return p, scanner.Err()
+}
+
+func (p Progress) add(char string, sent int, correct int) {
+	char = strings.ToLower(char)
+
+	c := p[char]
+	if c == nil {
+		c = &CharStats{}
+		p[char] = c
+	}
+	c.Sent += sent
+	c.Correct += correct
+}
+
+// Add the characters of a scored session.
+func (p Progress) Add(r *Result) {
+	for char, s := range r.Chars {
+		p.add(char, s.Sent, s.Correct)
+	}
+}
+
+// Merge another progress, like an imported results file.
+func (p Progress) Merge(o Progress) {
+	for char, s := range o {
+		p.add(char, s.Sent, s.Correct)
+	}
+}
+
+// Save writes the progress file, characters in order.
+func (p Progress) Save(name string) error {
+	chars := make([]string, 0, len(p))
+	for c := range p {
+		chars = append(chars, c)
+	}
+	sort.Strings(chars)
+
+	var sb strings.Builder
+	sb.WriteString("# cwpt2 progress: character sent correct\n")
+	for _, c := range chars {
+		fmt.Fprintf(&sb, "%s %d %d\n", c, p[c].Sent, p[c].Correct)
+	}
+
+	return os.WriteFile(name, []byte(sb.String()), 0644)
+}
+
+// Weight of a character for practice, from 1 for one always copied, to 5
+// for one always missed. A character with little or no history is near 3.
+func (p Progress) Weight(char string) float64 {
+	sent, correct := 0, 0
+
+	if c := p[strings.ToLower(char)]; c != nil {
+		sent, correct = c.Sent, c.Correct
+	}
+
+	// plus one each way so a new character is a coin toss
+	acc := float64(correct+1) / float64(sent+2)
+
+	return 1 + 4*(1-acc)
+}
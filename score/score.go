@@ -0,0 +1,198 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+// Package score compares what a student copied to what was sent, character
+// by character, using edit distance.
+package score
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/wa2nfn/cwpt/morse"
+)
+
+// OpKind is how a sent character lined up with the copy.
+type OpKind int
+
+const (
+	Match  OpKind = iota // copied correctly
+	Sub                  // copied as another character
+	Drop                 // sent but not copied
+	Insert               // copied but never sent
+)
+
+// Op is one step of the alignment.
+type Op struct {
+	Kind   OpKind
+	Sent   string // "" for an Insert
+	Copied string // "" for a Drop
+}
+
+// CharStats is how one character fared.
+type CharStats struct {
+	Sent    int
+	Correct int
+	Subs    int
+	Drops   int
+}
+
+// Accuracy of the character in percent.
+func (c *CharStats) Accuracy() float64 {
+	if c.Sent == 0 {
+		return 0
+	}
+	return 100 * float64(c.Correct) / float64(c.Sent)
+}
+
+// Result of comparing a copy with the sent text. The space between words
+// is aligned but not counted.
+type Result struct {
+	Ops        []Op
+	Chars      map[string]*CharStats
+	Confusions map[string]int // "b>6" means b was copied as 6
+	Inserted   map[string]int
+
+	Sent       int
+	Correct    int
+	Subs       int
+	Drops      int
+	Insertions int
+}
+
+// Compare aligns copied against sent. Case, extra spaces and the ebook2cw
+// commands in sent are ignored, a prosign like <BT> is one character.
+func Compare(sent string, copied string) *Result {
+	a := units(morse.Plain(sent))
+	b := units(strings.Join(strings.Fields(copied), " "))
+	r := newResult()
+
+	for _, op := range align(a, b) {
+		r.add(op)
+	}
+
+	return r
+}
+
+func newResult() *Result {
+	return &Result{
+		Chars:      make(map[string]*CharStats),
+		Confusions: make(map[string]int),
+		Inserted:   make(map[string]int),
+	}
+}
+
+// Merge adds the counts of o to r, for scoring item by item.
+func (r *Result) Merge(o *Result) {
+	for _, op := range o.Ops {
+		r.add(op)
+	}
+}
+
+// count one step of the alignment
+func (r *Result) add(op Op) {
+	r.Ops = append(r.Ops, op)
+
+	if op.Sent == " " || (op.Kind == Insert && op.Copied == " ") {
+		return
+	}
+
+	if op.Kind == Insert {
+		r.Insertions++
+		r.Inserted[op.Copied]++
+		return
+	}
+
+	c := r.Chars[op.Sent]
+	if c == nil {
+		c = &CharStats{}
+		r.Chars[op.Sent] = c
+	}
+	c.Sent++
+	r.Sent++
+
+	switch op.Kind {
+	case Match:
+		c.Correct++
+		r.Correct++
+	case Sub:
+		c.Subs++
+		r.Subs++
+		if op.Copied != " " {
+			r.Confusions[op.Sent+">"+op.Copied]++
+		}
+	case Drop:
+		c.Drops++
+		r.Drops++
+	}
+}
+
+// Accuracy in percent, an insertion counts against it like a miss.
+func (r *Result) Accuracy() float64 {
+	if r.Sent == 0 {
+		return 0
+	}
+
+	return 100 * float64(r.Correct) / float64(r.Sent+r.Insertions)
+}
+
+// Report writes the score, then each character worst first.
+func (r *Result) Report(w io.Writer) {
+	fmt.Fprintf(w, "\nCopy accuracy: %.1f%% (%d of %d characters correct)\n", r.Accuracy(), r.Correct, r.Sent)
+	fmt.Fprintf(w, "Substitutions: %d  Drops: %d  Insertions: %d\n", r.Subs, r.Drops, r.Insertions)
+
+	chars := make([]string, 0, len(r.Chars))
+	for c := range r.Chars {
+		chars = append(chars, c)
+	}
+	sort.Slice(chars, func(i, j int) bool {
+		ai, aj := r.Chars[chars[i]].Accuracy(), r.Chars[chars[j]].Accuracy()
+		if ai != aj {
+			return ai < aj
+		}
+		return chars[i] < chars[j]
+	})
+
+	fmt.Fprintf(w, "\nChar   Sent  Correct  Subs  Drops  Accuracy\n")
+	for _, c := range chars {
+		s := r.Chars[c]
+		fmt.Fprintf(w, "%-5s %5d  %7d  %4d  %5d  %7.1f%%\n", c, s.Sent, s.Correct, s.Subs, s.Drops, s.Accuracy())
+	}
+
+	if len(r.Confusions) > 0 {
+		fmt.Fprintf(w, "\nCopied as: %s\n", counts(r.Confusions))
+	}
+
+	if len(r.Inserted) > 0 {
+		fmt.Fprintf(w, "Inserted: %s\n", counts(r.Inserted))
+	}
+}
+
+// "b>6 (3), v>4 (1)" most often first
+func counts(m map[string]int) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if m[keys[i]] != m[keys[j]] {
+			return m[keys[i]] > m[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	out := []string{}
+	for _, k := range keys {
+		out = append(out, fmt.Sprintf("%s (%d)", k, m[k]))
+	}
+
+	return strings.Join(out, ", ")
+}
+
+// split text into what is sent as one character, lower case
+func units(text string) []string {
+	return morse.Chars(strings.ToLower(text))
+}
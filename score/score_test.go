@@ -0,0 +1,132 @@
+//
+// Copyright 2019, 2020 Bill Lanahan -  WA2NFN
+//
+
+package score
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name       string
+		sent       string
+		copied     string
+		correct    int
+		subs       int
+		drops      int
+		insertions int
+		confusion  string // a Confusions key that must be counted, "" for none
+	}{
+		{"all correct", "abc", "abc", 3, 0, 0, 0, ""},
+		{"substitution", "abc", "abd", 2, 1, 0, 0, "c>d"},
+		{"drop", "abc", "ac", 2, 0, 1, 0, ""},
+		{"insertion", "abc", "abxc", 3, 0, 0, 1, ""},
+		{"case and spaces ignored", "the cat", "  THE   CAT ", 6, 0, 0, 0, ""},
+		{"prosign is one character", "<BT> e", "<bt> e", 2, 0, 0, 0, ""},
+		{"ebook commands ignored", "|w20 abc |S500 de", "abc de", 5, 0, 0, 0, ""},
+		{"dropped word", "one two three", "one three", 8, 0, 3, 0, ""},
+		{"extra word", "one three", "one two three", 8, 0, 0, 3, ""},
+		{"nothing copied", "abc", "", 0, 0, 3, 0, ""},
+	}
+
+	for _, tt := range tests {
+		r := Compare(tt.sent, tt.copied)
+
+		if r.Correct != tt.correct || r.Subs != tt.subs || r.Drops != tt.drops || r.Insertions != tt.insertions {
+			t.Errorf("%s: Compare(%q, %q) correct/subs/drops/insertions = %d/%d/%d/%d, want %d/%d/%d/%d",
+				tt.name, tt.sent, tt.copied, r.Correct, r.Subs, r.Drops, r.Insertions,
+				tt.correct, tt.subs, tt.drops, tt.insertions)
+		}
+
+		if r.Sent != r.Correct+r.Subs+r.Drops {
+			t.Errorf("%s: sent %d is not correct+subs+drops", tt.name, r.Sent)
+		}
+
+		if tt.confusion != "" && r.Confusions[tt.confusion] != 1 {
+			t.Errorf("%s: confusions %v, want %s once", tt.name, r.Confusions, tt.confusion)
+		}
+	}
+}
+
+func TestAlignLongDrift(t *testing.T) {
+	// the copy is far longer than sent, the alignment must still reach the end
+	sent := []string{}
+	copied := []string{}
+	for i := 0; i < 300; i++ {
+		copied = append(copied, "x")
+		if i%3 == 0 {
+			sent = append(sent, "x")
+		}
+	}
+
+	drift := 0
+	for _, op := range align(sent, copied) {
+		switch op.Kind {
+		case Match:
+		case Insert:
+			drift++
+		default:
+			t.Fatalf("op %+v, want only matches and inserts", op)
+		}
+	}
+
+	if want := len(copied) - len(sent); drift != want {
+		t.Errorf("inserts = %d, want %d", drift, want)
+	}
+}
+
+// words of a long session, each different so a copy can only line up one way
+func sessionWords(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = string(rune('a'+i%26)) + string(rune('a'+i/26%26)) + string(rune('a'+i/676%26)) + "e"
+	}
+	return words
+}
+
+func TestComparePartialCopy(t *testing.T) {
+	sent := sessionWords(1000)
+
+	tests := []struct {
+		name   string
+		copied []string
+		drops  int // of sent characters, 4 a word
+	}{
+		{"first tenth", sent[:100], 4 * 900},
+		{"last tenth", sent[900:], 4 * 900},
+		{"middle missed", append(append([]string{}, sent[:450]...), sent[550:]...), 4 * 100},
+		{"nothing", nil, 4 * 1000},
+	}
+
+	for _, tt := range tests {
+		r := Compare(strings.Join(sent, " "), strings.Join(tt.copied, " "))
+
+		if r.Drops != tt.drops || r.Correct != r.Sent-tt.drops || r.Subs != 0 || r.Insertions != 0 {
+			t.Errorf("%s: correct/subs/drops/insertions = %d/%d/%d/%d, want %d/0/%d/0",
+				tt.name, r.Correct, r.Subs, r.Drops, r.Insertions, r.Sent-tt.drops, tt.drops)
+		}
+	}
+}
+
+func TestCompareMemory(t *testing.T) {
+	// 3000 words against a short or empty copy, a full table would be gigabytes
+	sent := strings.Join(sessionWords(3000), " ")
+
+	for _, copied := range []string{"", sent[:len(sent)/10]} {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		Compare(sent, copied)
+
+		runtime.ReadMemStats(&after)
+		if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 32<<20 {
+			t.Errorf("Compare of %d sent and %d copied characters allocated %d MiB, want under 32",
+				len(sent), len(copied), alloc>>20)
+		}
+	}
+}